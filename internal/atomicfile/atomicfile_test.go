@@ -0,0 +1,28 @@
+package atomicfile
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestWriteFile(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "nested", "data.json")
+    if err := WriteFile(path, []byte("first"), 0o600); err != nil {
+        t.Fatalf("WriteFile error: %v", err)
+    }
+    if err := WriteFile(path, []byte("second"), 0o600); err != nil {
+        t.Fatalf("WriteFile error: %v", err)
+    }
+    got, err := os.ReadFile(path)
+    if err != nil || string(got) != "second" {
+        t.Fatalf("expected second, got %q (%v)", got, err)
+    }
+    entries, _ := os.ReadDir(filepath.Dir(path))
+    if len(entries) != 1 {
+        t.Fatalf("expected temporary files to be cleaned up, found %d entries", len(entries))
+    }
+    if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+        t.Fatalf("expected mode 0600, got %v", info.Mode().Perm())
+    }
+}
@@ -1,10 +1,28 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
+
 	"github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/cli"
 )
 
 func main() {
-	cli.Run(os.Stdin, os.Stdout)
-}
\ No newline at end of file
+	savePath := flag.String("save", "", "path to the Pokédex save file (default $XDG_DATA_HOME/pokedex/save.json)")
+	shinyChance := flag.Float64("shiny-chance", cli.DefaultShinyChance, "probability that a caught Pokémon is shiny")
+	flag.Parse()
+	if *shinyChance < 0 || *shinyChance > 1 {
+		fmt.Fprintln(os.Stderr, "-shiny-chance must be between 0 and 1")
+		os.Exit(2)
+	}
+
+	opts := []cli.Option{cli.WithShinyChance(*shinyChance)}
+	if *savePath != "" {
+		opts = append(opts, cli.WithSavePath(*savePath))
+	}
+	if err := cli.Run(os.Stdin, os.Stdout, opts...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
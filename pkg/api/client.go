@@ -1,16 +1,88 @@
 package api
 
 import (
+    "context"
     "encoding/json"
     "io"
     "net/http"
+    "strings"
+    "time"
 
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/internal/pokecache"
 )
 
-var PokeAPIBase = "https://pokeapi.co/api/v2/"
-var LocationAreaBase = PokeAPIBase + "location-area/"
-var Cache *pokecache.Cache
+const DefaultBaseURL = "https://pokeapi.co/api/v2/"
+const DefaultUserAgent = "boot.dev-go-pokedex-cli"
+
+type Client struct {
+    baseURL    string
+    httpClient *http.Client
+    cache      *pokecache.Cache
+    userAgent  string
+    timeout    time.Duration
+    retry      RetryPolicy
+    onRetry    RetryHook
+    limiter    *limiter
+    inFlight   semaphore
+}
+
+type Option func(*Client)
+
+func WithBaseURL(url string) Option {
+    return func(c *Client) {
+        if !strings.HasSuffix(url, "/") {
+            url += "/"
+        }
+        c.baseURL = url
+    }
+}
+
+func WithHTTPClient(hc *http.Client) Option {
+    return func(c *Client) { c.httpClient = hc }
+}
+
+func WithCache(cache *pokecache.Cache) Option {
+    return func(c *Client) { c.cache = cache }
+}
+
+func WithUserAgent(ua string) Option {
+    return func(c *Client) { c.userAgent = ua }
+}
+
+func WithTimeout(d time.Duration) Option {
+    return func(c *Client) { c.timeout = d }
+}
+
+func NewClient(opts ...Option) *Client {
+    c := &Client{
+        baseURL:    DefaultBaseURL,
+        httpClient: http.DefaultClient,
+        userAgent:  DefaultUserAgent,
+        limiter:    newLimiter(DefaultRequestsPerSecond, DefaultBurst),
+        inFlight:   newSemaphore(DefaultMaxConcurrent),
+    }
+    for _, opt := range opts {
+        opt(c)
+    }
+    if c.timeout > 0 {
+        hc := *c.httpClient
+        hc.Timeout = c.timeout
+        c.httpClient = &hc
+    }
+    return c
+}
+
+func (c *Client) BaseURL() string {
+    return c.baseURL
+}
+
+func (c *Client) LocationAreasURL() string {
+    return c.baseURL + "location-area/"
+}
+
+func (c *Client) LocationAreaURL(name string) string {
+    return c.LocationAreasURL() + name + "/"
+}
 
 type LocationAreaList struct {
     Count    int      `json:"count"`
@@ -36,57 +108,45 @@ type PokemonEncounter struct {
 }
 
 type Pokemon struct {
-    Name           string
-    BaseExperience int
-    Height         int
-    Weight         int
-    Stats          map[string]int
-    Types          []string
-}
-
-func FetchLocationAreas(url string) (*LocationAreaList, error) {
-    if Cache != nil {
-        if b, ok := Cache.Get(url); ok {
-            var list LocationAreaList
-            if err := json.Unmarshal(b, &list); err == nil {
-                return &list, nil
-            }
-        }
-    }
+    Name           string         `json:"name"`
+    Species        string         `json:"species,omitempty"`
+    BaseExperience int            `json:"base_experience"`
+    Height         int            `json:"height"`
+    Weight         int            `json:"weight"`
+    Stats          map[string]int `json:"stats"`
+    // EffortYield is the effort values earned for defeating this Pokémon.
+    EffortYield    map[string]int `json:"effort_yield,omitempty"`
+    Types          []string       `json:"types"`
+    // Moves is the full learnset. It is not saved with caught Pokémon;
+    // fetch it again through the cached client when needed.
+    Moves          []PokemonMove  `json:"-"`
+}
 
-    resp, err := http.Get(url)
-    if err != nil {
-        return nil, err
+func (p Pokemon) SpeciesName() string {
+    if p.Species != "" {
+        return p.Species
     }
-    defer resp.Body.Close()
+    return p.Name
+}
 
-    body, err := io.ReadAll(resp.Body)
-    if err != nil {
+func (c *Client) do(ctx context.Context, url string) ([]byte, error) {
+    if err := c.limiter.Wait(ctx); err != nil {
         return nil, err
     }
-
-    var list LocationAreaList
-    if err := json.Unmarshal(body, &list); err != nil {
+    if err := c.inFlight.Acquire(ctx); err != nil {
         return nil, err
     }
+    defer c.inFlight.Release()
 
-    if Cache != nil {
-        Cache.Add(url, body)
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+    if err != nil {
+        return nil, err
     }
-    return &list, nil
-}
-
-func FetchLocationAreaDetail(url string) (*LocationAreaDetail, error) {
-    if Cache != nil {
-        if b, ok := Cache.Get(url); ok {
-            var detail LocationAreaDetail
-            if err := json.Unmarshal(b, &detail); err == nil {
-                return &detail, nil
-            }
-        }
+    if c.userAgent != "" {
+        req.Header.Set("User-Agent", c.userAgent)
     }
 
-    resp, err := http.Get(url)
+    resp, err := c.httpClient.Do(req)
     if err != nil {
         return nil, err
     }
@@ -96,84 +156,124 @@ func FetchLocationAreaDetail(url string) (*LocationAreaDetail, error) {
     if err != nil {
         return nil, err
     }
-
-    var detail LocationAreaDetail
-    if err := json.Unmarshal(body, &detail); err != nil {
+    if err := checkStatus(resp, url, body); err != nil {
         return nil, err
     }
+    return body, nil
+}
 
-    if Cache != nil {
-        Cache.Add(url, body)
+// resolveURL keeps resource links returned by PokeAPI pointing at this
+// client's base URL, so mirrors are not bypassed when following them.
+func (c *Client) resolveURL(url string) string {
+    switch {
+    case strings.HasPrefix(url, DefaultBaseURL):
+        return c.baseURL + strings.TrimPrefix(url, DefaultBaseURL)
+    case strings.HasPrefix(url, "/"):
+        return c.baseURL + strings.TrimPrefix(url, "/")
     }
-    return &detail, nil
+    return url
 }
 
-func FetchPokemon(name string) (*Pokemon, error) {
-    url := PokeAPIBase + "pokemon/" + name + "/"
-
-    if Cache != nil {
-        if b, ok := Cache.Get(url); ok {
-            var p struct {
-                Name           string `json:"name"`
-                BaseExperience int    `json:"base_experience"`
-            }
-            if err := json.Unmarshal(b, &p); err == nil {
-                return &Pokemon{Name: p.Name, BaseExperience: p.BaseExperience}, nil
+func fetchJSON[T any](ctx context.Context, c *Client, url string) (*T, error) {
+    url = c.resolveURL(url)
+    if c.cache != nil {
+        if b, ok := c.cache.Get(url); ok {
+            var v T
+            if err := json.Unmarshal(b, &v); err == nil {
+                return &v, nil
             }
         }
     }
 
-    resp, err := http.Get(url)
+    body, err := c.get(ctx, url)
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
 
-    body, err := io.ReadAll(resp.Body)
-    if err != nil {
+    var v T
+    if err := json.Unmarshal(body, &v); err != nil {
         return nil, err
     }
 
-    var p struct {
-        Name           string `json:"name"`
-        BaseExperience int    `json:"base_experience"`
-        Height         int    `json:"height"`
-        Weight         int    `json:"weight"`
-        Stats []struct {
-            BaseStat int `json:"base_stat"`
-            Stat     struct{
-                Name string `json:"name"`
-            } `json:"stat"`
-        } `json:"stats"`
-        Types []struct {
-            Type struct{
-                Name string `json:"name"`
-            } `json:"type"`
-        } `json:"types"`
-    }
-    if err := json.Unmarshal(body, &p); err != nil {
-        return nil, err
+    if c.cache != nil {
+        c.cache.Add(url, body)
     }
+    return &v, nil
+}
 
-    if Cache != nil {
-        Cache.Add(url, body)
-    }
+func (c *Client) FetchLocationAreas(ctx context.Context, url string) (*LocationAreaList, error) {
+    return fetchJSON[LocationAreaList](ctx, c, url)
+}
+
+func (c *Client) FetchLocationAreaDetail(ctx context.Context, url string) (*LocationAreaDetail, error) {
+    return fetchJSON[LocationAreaDetail](ctx, c, url)
+}
 
+type pokemonResponse struct {
+    Name           string `json:"name"`
+    BaseExperience int    `json:"base_experience"`
+    Height         int    `json:"height"`
+    Weight         int    `json:"weight"`
+    Species        namedAPIResource `json:"species"`
+    Stats []struct {
+        BaseStat int `json:"base_stat"`
+        Effort   int `json:"effort"`
+        Stat     struct{
+            Name string `json:"name"`
+        } `json:"stat"`
+    } `json:"stats"`
+    Types []struct {
+        Type struct{
+            Name string `json:"name"`
+        } `json:"type"`
+    } `json:"types"`
+    Moves []pokemonMoveResponse `json:"moves"`
+}
+
+func (p *pokemonResponse) toPokemon() *Pokemon {
     statsMap := make(map[string]int)
+    var effort map[string]int
     for _, s := range p.Stats {
         statsMap[s.Stat.Name] = s.BaseStat
+        if s.Effort > 0 {
+            if effort == nil { effort = make(map[string]int) }
+            effort[s.Stat.Name] = s.Effort
+        }
     }
     types := make([]string, 0, len(p.Types))
     for _, t := range p.Types {
         types = append(types, t.Type.Name)
     }
 
+    var moves []PokemonMove
+    for _, m := range p.Moves {
+        for _, d := range m.VersionGroupDetails {
+            moves = append(moves, PokemonMove{
+                Name: m.Move.Name,
+                LearnMethod: d.MoveLearnMethod.Name,
+                LevelLearned: d.LevelLearnedAt,
+                VersionGroup: d.VersionGroup.Name,
+            })
+        }
+    }
+
     return &Pokemon{
         Name: p.Name,
+        Species: p.Species.Name,
         BaseExperience: p.BaseExperience,
         Height: p.Height,
         Weight: p.Weight,
         Stats: statsMap,
+        EffortYield: effort,
         Types: types,
-    }, nil
+        Moves: moves,
+    }
+}
+
+func (c *Client) FetchPokemon(ctx context.Context, name string) (*Pokemon, error) {
+    p, err := fetchJSON[pokemonResponse](ctx, c, c.baseURL+"pokemon/"+name+"/")
+    if err != nil {
+        return nil, err
+    }
+    return p.toPokemon(), nil
 }
@@ -1,9 +1,14 @@
 package api
 
 import (
+    "context"
     "net/http"
     "net/http/httptest"
+    "reflect"
     "testing"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/internal/pokecache"
 )
 
 func TestFetchLocationAreas(t *testing.T) {
@@ -24,11 +29,9 @@ func TestFetchLocationAreas(t *testing.T) {
     }))
     defer ts.Close()
 
-    PokeAPIBase = ts.URL + "/"
-    LocationAreaBase = PokeAPIBase + "location-area/"
-    Cache = nil
+    c := NewClient(WithBaseURL(ts.URL))
 
-    list, err := FetchLocationAreas(LocationAreaBase)
+    list, err := c.FetchLocationAreas(context.Background(), c.LocationAreasURL())
     if err != nil {
         t.Fatalf("FetchLocationAreas error: %v", err)
     }
@@ -53,11 +56,9 @@ func TestFetchLocationAreaDetail(t *testing.T) {
     }))
     defer ts.Close()
 
-    PokeAPIBase = ts.URL + "/"
-    LocationAreaBase = PokeAPIBase + "location-area/"
-    Cache = nil
+    c := NewClient(WithBaseURL(ts.URL))
 
-    detail, err := FetchLocationAreaDetail(LocationAreaBase + "area-1/")
+    detail, err := c.FetchLocationAreaDetail(context.Background(), c.LocationAreaURL("area-1"))
     if err != nil {
         t.Fatalf("FetchLocationAreaDetail error: %v", err)
     }
@@ -75,8 +76,9 @@ func TestFetchPokemon(t *testing.T) {
   "height": 4,
   "weight": 60,
   "stats": [
-    {"base_stat": 35, "stat": {"name": "hp"}},
-    {"base_stat": 55, "stat": {"name": "attack"}}
+    {"base_stat": 35, "effort": 0, "stat": {"name": "hp"}},
+    {"base_stat": 55, "effort": 0, "stat": {"name": "attack"}},
+    {"base_stat": 90, "effort": 2, "stat": {"name": "speed"}}
   ],
   "types": [
     {"type": {"name": "electric"}}
@@ -88,10 +90,9 @@ func TestFetchPokemon(t *testing.T) {
     }))
     defer ts.Close()
 
-    PokeAPIBase = ts.URL + "/"
-    Cache = nil
+    c := NewClient(WithBaseURL(ts.URL))
 
-    p, err := FetchPokemon("pikachu")
+    p, err := c.FetchPokemon(context.Background(), "pikachu")
     if err != nil {
         t.Fatalf("FetchPokemon error: %v", err)
     }
@@ -104,4 +105,77 @@ func TestFetchPokemon(t *testing.T) {
     if p.Stats["hp"] != 35 {
         t.Fatalf("expected hp 35, got %d", p.Stats["hp"])
     }
+    if len(p.EffortYield) != 1 || p.EffortYield["speed"] != 2 {
+        t.Fatalf("expected an effort yield of 2 speed, got %v", p.EffortYield)
+    }
+}
+
+func TestClientsAreIndependent(t *testing.T) {
+    newServer := func(area string) *httptest.Server {
+        return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            if r.Header.Get("User-Agent") != "test-agent" {
+                t.Errorf("expected user agent test-agent, got %q", r.Header.Get("User-Agent"))
+            }
+            w.Write([]byte(`{"count": 1, "results": [{"name": "` + area + `"}]}`))
+        }))
+    }
+    tsA := newServer("area-a")
+    defer tsA.Close()
+    tsB := newServer("area-b")
+    defer tsB.Close()
+
+    a := NewClient(WithBaseURL(tsA.URL), WithUserAgent("test-agent"), WithTimeout(time.Second))
+    b := NewClient(WithBaseURL(tsB.URL), WithUserAgent("test-agent"), WithCache(pokecache.NewCache(time.Minute)))
+
+    listA, err := a.FetchLocationAreas(context.Background(), a.LocationAreasURL())
+    if err != nil {
+        t.Fatalf("client a error: %v", err)
+    }
+    listB, err := b.FetchLocationAreas(context.Background(), b.LocationAreasURL())
+    if err != nil {
+        t.Fatalf("client b error: %v", err)
+    }
+    if listA.Results[0].Name != "area-a" || listB.Results[0].Name != "area-b" {
+        t.Fatalf("clients leaked state: got %q and %q", listA.Results[0].Name, listB.Results[0].Name)
+    }
+}
+
+func TestFetchPokemonCacheHitMatchesFreshFetch(t *testing.T) {
+    calls := 0
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        w.Write([]byte(`{
+  "name": "pikachu",
+  "base_experience": 112,
+  "height": 4,
+  "weight": 60,
+  "stats": [
+    {"base_stat": 35, "stat": {"name": "hp"}},
+    {"base_stat": 55, "stat": {"name": "attack"}}
+  ],
+  "types": [
+    {"type": {"name": "electric"}}
+  ]
+}`))
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL), WithCache(pokecache.NewCache(time.Minute)))
+
+    fresh, err := c.FetchPokemon(context.Background(), "pikachu")
+    if err != nil {
+        t.Fatalf("first FetchPokemon error: %v", err)
+    }
+    cached, err := c.FetchPokemon(context.Background(), "pikachu")
+    if err != nil {
+        t.Fatalf("cached FetchPokemon error: %v", err)
+    }
+    if calls != 1 {
+        t.Fatalf("expected second fetch to hit the cache, server saw %d calls", calls)
+    }
+    if !reflect.DeepEqual(fresh, cached) {
+        t.Fatalf("cache hit differs from fresh fetch:\nfresh:  %+v\ncached: %+v", fresh, cached)
+    }
+    if cached.Height != 4 || cached.Weight != 60 || len(cached.Types) != 1 {
+        t.Fatalf("cache hit lost fields: %+v", cached)
+    }
 }
@@ -0,0 +1,88 @@
+package api
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+)
+
+const maxBodySnippet = 200
+
+var ErrNotFound = errors.New("resource not found")
+
+type RateLimitError struct {
+    RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+    if e.RetryAfter > 0 {
+        return fmt.Sprintf("rate limited by PokeAPI, retry after %s", e.RetryAfter)
+    }
+    return "rate limited by PokeAPI"
+}
+
+type ServerError struct {
+    StatusCode int
+    Body       string
+}
+
+func (e *ServerError) Error() string {
+    if e.Body == "" {
+        return fmt.Sprintf("PokeAPI server error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+    }
+    return fmt.Sprintf("PokeAPI server error: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+type StatusError struct {
+    StatusCode int
+    Body       string
+}
+
+func (e *StatusError) Error() string {
+    return fmt.Sprintf("unexpected PokeAPI response: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func checkStatus(resp *http.Response, url string, body []byte) error {
+    switch {
+    case resp.StatusCode >= 200 && resp.StatusCode < 300:
+        return nil
+    case resp.StatusCode == http.StatusNotFound:
+        return fmt.Errorf("%w: %s", ErrNotFound, url)
+    case resp.StatusCode == http.StatusTooManyRequests:
+        return &RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+    case resp.StatusCode >= 500:
+        return &ServerError{StatusCode: resp.StatusCode, Body: snippet(body)}
+    default:
+        return &StatusError{StatusCode: resp.StatusCode, Body: snippet(body)}
+    }
+}
+
+func parseRetryAfter(v string, now time.Time) time.Duration {
+    v = strings.TrimSpace(v)
+    if v == "" {
+        return 0
+    }
+    if secs, err := strconv.Atoi(v); err == nil {
+        if secs < 0 {
+            return 0
+        }
+        return time.Duration(secs) * time.Second
+    }
+    if t, err := http.ParseTime(v); err == nil {
+        if d := t.Sub(now); d > 0 {
+            return d
+        }
+    }
+    return 0
+}
+
+func snippet(body []byte) string {
+    s := strings.TrimSpace(string(body))
+    if len(s) > maxBodySnippet {
+        s = s[:maxBodySnippet] + "..."
+    }
+    return s
+}
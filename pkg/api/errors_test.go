@@ -0,0 +1,81 @@
+package api
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestFetchPokemonStatusErrors(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/pokemon/pikchu/":
+            http.Error(w, "Not Found", http.StatusNotFound)
+        case "/pokemon/busy/":
+            w.Header().Set("Retry-After", "2")
+            w.WriteHeader(http.StatusTooManyRequests)
+        case "/pokemon/broken/":
+            w.WriteHeader(http.StatusBadGateway)
+            w.Write([]byte("upstream " + strings.Repeat("x", 500)))
+        default:
+            w.WriteHeader(http.StatusTeapot)
+        }
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    _, err := c.FetchPokemon(context.Background(), "pikchu")
+    if !errors.Is(err, ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+
+    _, err = c.FetchPokemon(context.Background(), "busy")
+    var rl *RateLimitError
+    if !errors.As(err, &rl) {
+        t.Fatalf("expected *RateLimitError, got %v", err)
+    }
+    if rl.RetryAfter != 2*time.Second {
+        t.Fatalf("expected retry after 2s, got %s", rl.RetryAfter)
+    }
+
+    _, err = c.FetchPokemon(context.Background(), "broken")
+    var se *ServerError
+    if !errors.As(err, &se) {
+        t.Fatalf("expected *ServerError, got %v", err)
+    }
+    if se.StatusCode != http.StatusBadGateway {
+        t.Fatalf("expected status 502, got %d", se.StatusCode)
+    }
+    if !strings.HasPrefix(se.Body, "upstream") || len(se.Body) > maxBodySnippet+3 {
+        t.Fatalf("unexpected body snippet %q", se.Body)
+    }
+
+    _, err = c.FetchPokemon(context.Background(), "teapot")
+    var st *StatusError
+    if !errors.As(err, &st) || st.StatusCode != http.StatusTeapot {
+        t.Fatalf("expected *StatusError with 418, got %v", err)
+    }
+}
+
+func TestParseRetryAfter(t *testing.T) {
+    now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+    cases := []struct {
+        input    string
+        expected time.Duration
+    }{
+        {input: "", expected: 0},
+        {input: "5", expected: 5 * time.Second},
+        {input: "-1", expected: 0},
+        {input: now.Add(90 * time.Second).Format(http.TimeFormat), expected: 90 * time.Second},
+        {input: "soon", expected: 0},
+    }
+    for _, c := range cases {
+        if got := parseRetryAfter(c.input, now); got != c.expected {
+            t.Errorf("input %q: expected %s, got %s", c.input, c.expected, got)
+        }
+    }
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "sort"
+    "strings"
+)
+
+type EvolutionChain struct {
+    ID    int
+    Chain ChainLink
+}
+
+type ChainLink struct {
+    Species          string
+    EvolutionDetails []EvolutionDetail
+    EvolvesTo        []ChainLink
+}
+
+type EvolutionDetail struct {
+    Trigger      string
+    MinLevel     int
+    Item         string
+    HeldItem     string
+    MinHappiness int
+    TimeOfDay    string
+
+    // Gender is 1 for female and 2 for male, as in PokeAPI; 0 is either.
+    Gender             int
+    Location           string
+    KnownMove          string
+    KnownMoveType      string
+    MinAffection       int
+    MinBeauty          int
+    NeedsOverworldRain bool
+    PartySpecies       string
+    PartyType          string
+    // RelativePhysicalStats compares attack with defense: 1 for greater,
+    // 0 for equal and -1 for less. Nil means no requirement.
+    RelativePhysicalStats *int
+    TradeSpecies          string
+    TurnUpsideDown        bool
+    // Other lists any further conditions PokeAPI sets that are not parsed.
+    Other []string
+}
+
+type evolutionChainResponse struct {
+    ID    int               `json:"id"`
+    Chain chainLinkResponse `json:"chain"`
+}
+
+type chainLinkResponse struct {
+    Species          namedAPIResource          `json:"species"`
+    EvolutionDetails []evolutionDetailResponse `json:"evolution_details"`
+    EvolvesTo        []chainLinkResponse       `json:"evolves_to"`
+}
+
+type evolutionDetailResponse struct {
+    Trigger               namedAPIResource  `json:"trigger"`
+    MinLevel              *int              `json:"min_level"`
+    Item                  *namedAPIResource `json:"item"`
+    HeldItem              *namedAPIResource `json:"held_item"`
+    MinHappiness          *int              `json:"min_happiness"`
+    TimeOfDay             string            `json:"time_of_day"`
+    Gender                *int              `json:"gender"`
+    Location              *namedAPIResource `json:"location"`
+    KnownMove             *namedAPIResource `json:"known_move"`
+    KnownMoveType         *namedAPIResource `json:"known_move_type"`
+    MinAffection          *int              `json:"min_affection"`
+    MinBeauty             *int              `json:"min_beauty"`
+    NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+    PartySpecies          *namedAPIResource `json:"party_species"`
+    PartyType             *namedAPIResource `json:"party_type"`
+    RelativePhysicalStats *int              `json:"relative_physical_stats"`
+    TradeSpecies          *namedAPIResource `json:"trade_species"`
+    TurnUpsideDown        bool              `json:"turn_upside_down"`
+
+    other []string
+}
+
+// knownDetailFields are the evolution_details keys decoded above.
+var knownDetailFields = map[string]bool{
+    "trigger": true, "min_level": true, "item": true, "held_item": true,
+    "min_happiness": true, "time_of_day": true, "gender": true, "location": true,
+    "known_move": true, "known_move_type": true, "min_affection": true,
+    "min_beauty": true, "needs_overworld_rain": true, "party_species": true,
+    "party_type": true, "relative_physical_stats": true, "trade_species": true,
+    "turn_upside_down": true,
+}
+
+// UnmarshalJSON also records fields PokeAPI may add later, so callers can
+// refuse an evolution whose conditions they do not understand.
+func (r *evolutionDetailResponse) UnmarshalJSON(data []byte) error {
+    type plain evolutionDetailResponse
+    if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+        return err
+    }
+    var fields map[string]json.RawMessage
+    if err := json.Unmarshal(data, &fields); err != nil {
+        return err
+    }
+    for name, raw := range fields {
+        if knownDetailFields[name] {
+            continue
+        }
+        switch strings.TrimSpace(string(raw)) {
+        case "null", "false", `""`, "0", "[]", "{}":
+            continue
+        }
+        r.other = append(r.other, name)
+    }
+    sort.Strings(r.other)
+    return nil
+}
+
+func nameOf(r *namedAPIResource) string {
+    if r == nil {
+        return ""
+    }
+    return r.Name
+}
+
+func intOf(n *int) int {
+    if n == nil {
+        return 0
+    }
+    return *n
+}
+
+func (d *evolutionDetailResponse) toDetail() EvolutionDetail {
+    return EvolutionDetail{
+        Trigger:               d.Trigger.Name,
+        MinLevel:              intOf(d.MinLevel),
+        Item:                  nameOf(d.Item),
+        HeldItem:              nameOf(d.HeldItem),
+        MinHappiness:          intOf(d.MinHappiness),
+        TimeOfDay:             d.TimeOfDay,
+        Gender:                intOf(d.Gender),
+        Location:              nameOf(d.Location),
+        KnownMove:             nameOf(d.KnownMove),
+        KnownMoveType:         nameOf(d.KnownMoveType),
+        MinAffection:          intOf(d.MinAffection),
+        MinBeauty:             intOf(d.MinBeauty),
+        NeedsOverworldRain:    d.NeedsOverworldRain,
+        PartySpecies:          nameOf(d.PartySpecies),
+        PartyType:             nameOf(d.PartyType),
+        RelativePhysicalStats: d.RelativePhysicalStats,
+        TradeSpecies:          nameOf(d.TradeSpecies),
+        TurnUpsideDown:        d.TurnUpsideDown,
+        Other:                 d.other,
+    }
+}
+
+func (r *chainLinkResponse) toChainLink() ChainLink {
+    link := ChainLink{Species: r.Species.Name}
+    for i := range r.EvolutionDetails {
+        link.EvolutionDetails = append(link.EvolutionDetails, r.EvolutionDetails[i].toDetail())
+    }
+    for i := range r.EvolvesTo {
+        link.EvolvesTo = append(link.EvolvesTo, r.EvolvesTo[i].toChainLink())
+    }
+    return link
+}
+
+func (c *Client) FetchEvolutionChain(ctx context.Context, url string) (*EvolutionChain, error) {
+    r, err := fetchJSON[evolutionChainResponse](ctx, c, url)
+    if err != nil {
+        return nil, err
+    }
+    return &EvolutionChain{ID: r.ID, Chain: r.Chain.toChainLink()}, nil
+}
+
+func (l *ChainLink) Find(species string) *ChainLink {
+    if l.Species == species {
+        return l
+    }
+    for i := range l.EvolvesTo {
+        if found := l.EvolvesTo[i].Find(species); found != nil {
+            return found
+        }
+    }
+    return nil
+}
+
+func (l ChainLink) Conditions() string {
+    parts := make([]string, 0, len(l.EvolutionDetails))
+    for _, d := range l.EvolutionDetails {
+        parts = append(parts, d.String())
+    }
+    return strings.Join(parts, " or ")
+}
+
+func (d EvolutionDetail) String() string {
+    var parts []string
+    switch d.Trigger {
+    case "level-up":
+        if d.MinLevel > 0 {
+            parts = append(parts, fmt.Sprintf("level %d", d.MinLevel))
+        } else {
+            parts = append(parts, "level up")
+        }
+    case "use-item":
+        parts = append(parts, "use "+d.Item)
+    case "trade":
+        parts = append(parts, "trade")
+    default:
+        if d.Trigger != "" {
+            parts = append(parts, d.Trigger)
+        }
+    }
+    if d.HeldItem != "" {
+        parts = append(parts, "holding "+d.HeldItem)
+    }
+    if d.MinHappiness > 0 {
+        parts = append(parts, fmt.Sprintf("happiness %d+", d.MinHappiness))
+    }
+    switch d.TimeOfDay {
+    case "":
+    case "night":
+        parts = append(parts, "at night")
+    default:
+        parts = append(parts, "during the "+d.TimeOfDay)
+    }
+    return strings.Join(append(parts, d.ExtraConditions()...), ", ")
+}
+
+// ExtraConditions describes every condition beyond trigger, level, item,
+// held item, happiness and time of day.
+func (d EvolutionDetail) ExtraConditions() []string {
+    var parts []string
+    switch d.Gender {
+    case 1:
+        parts = append(parts, "female")
+    case 2:
+        parts = append(parts, "male")
+    }
+    if d.Location != "" {
+        parts = append(parts, "location "+d.Location)
+    }
+    if d.KnownMove != "" {
+        parts = append(parts, "move "+d.KnownMove)
+    }
+    if d.KnownMoveType != "" {
+        parts = append(parts, "a "+d.KnownMoveType+" move")
+    }
+    if d.MinAffection > 0 {
+        parts = append(parts, fmt.Sprintf("affection %d+", d.MinAffection))
+    }
+    if d.MinBeauty > 0 {
+        parts = append(parts, fmt.Sprintf("beauty %d+", d.MinBeauty))
+    }
+    if d.NeedsOverworldRain {
+        parts = append(parts, "overworld rain")
+    }
+    if d.PartySpecies != "" {
+        parts = append(parts, d.PartySpecies+" in the party")
+    }
+    if d.PartyType != "" {
+        parts = append(parts, "a "+d.PartyType+" type in the party")
+    }
+    if d.RelativePhysicalStats != nil {
+        switch *d.RelativePhysicalStats {
+        case 1:
+            parts = append(parts, "attack above defense")
+        case 0:
+            parts = append(parts, "attack equal to defense")
+        default:
+            parts = append(parts, "attack below defense")
+        }
+    }
+    if d.TradeSpecies != "" {
+        parts = append(parts, "trade for "+d.TradeSpecies)
+    }
+    if d.TurnUpsideDown {
+        parts = append(parts, "the console upside down")
+    }
+    for _, o := range d.Other {
+        parts = append(parts, strings.ReplaceAll(o, "_", " "))
+    }
+    return parts
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+const eeveeChainJSON = `{
+  "id": 67,
+  "chain": {
+    "species": {"name": "eevee"},
+    "evolution_details": [],
+    "evolves_to": [
+      {
+        "species": {"name": "vaporeon"},
+        "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "min_level": null, "time_of_day": ""}],
+        "evolves_to": []
+      },
+      {
+        "species": {"name": "sylveon"},
+        "evolution_details": [{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}, "min_affection": 2, "min_friendship": 3, "gender": null, "turn_upside_down": false}],
+        "evolves_to": []
+      },
+      {
+        "species": {"name": "umbreon"},
+        "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}],
+        "evolves_to": []
+      }
+    ]
+  }
+}`
+
+func TestFetchEvolutionChain(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/evolution-chain/67/" {
+            w.Write([]byte(eeveeChainJSON))
+            return
+        }
+        http.NotFound(w, r)
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    chain, err := c.FetchEvolutionChain(context.Background(), DefaultBaseURL+"evolution-chain/67/")
+    if err != nil {
+        t.Fatalf("FetchEvolutionChain error: %v", err)
+    }
+    if chain.ID != 67 || chain.Chain.Species != "eevee" || len(chain.Chain.EvolvesTo) != 3 {
+        t.Fatalf("unexpected chain: %+v", chain)
+    }
+
+    umbreon := chain.Chain.Find("umbreon")
+    if umbreon == nil {
+        t.Fatalf("expected to find umbreon in chain")
+    }
+    if got := umbreon.Conditions(); got != "level up, happiness 160+, at night" {
+        t.Fatalf("unexpected umbreon conditions %q", got)
+    }
+    if got := chain.Chain.Find("vaporeon").Conditions(); got != "use water-stone" {
+        t.Fatalf("unexpected vaporeon conditions %q", got)
+    }
+    if got := chain.Chain.Find("sylveon").EvolutionDetails[0]; got.KnownMoveType != "fairy" || got.MinAffection != 2 || len(got.Other) != 1 || got.Other[0] != "min_friendship" {
+        t.Fatalf("unexpected sylveon detail %+v", got)
+    }
+    if chain.Chain.Find("pikachu") != nil {
+        t.Fatalf("did not expect to find pikachu")
+    }
+}
+
+func TestEvolutionDetailString(t *testing.T) {
+    cases := []struct {
+        detail   EvolutionDetail
+        expected string
+    }{
+        {detail: EvolutionDetail{Trigger: "level-up", MinLevel: 16}, expected: "level 16"},
+        {detail: EvolutionDetail{Trigger: "trade", HeldItem: "metal-coat"}, expected: "trade, holding metal-coat"},
+        {detail: EvolutionDetail{Trigger: "level-up", MinHappiness: 220, TimeOfDay: "day"}, expected: "level up, happiness 220+, during the day"},
+        {detail: EvolutionDetail{Trigger: "shed"}, expected: "shed"},
+        {detail: EvolutionDetail{Trigger: "level-up", Location: "eterna-forest"}, expected: "level up, location eterna-forest"},
+        {detail: EvolutionDetail{Trigger: "level-up", MinLevel: 10, Gender: 1}, expected: "level 10, female"},
+    }
+    for _, c := range cases {
+        if got := c.detail.String(); got != c.expected {
+            t.Errorf("%+v: expected %q, got %q", c.detail, c.expected, got)
+        }
+    }
+}
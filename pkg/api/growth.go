@@ -0,0 +1,62 @@
+package api
+
+import (
+    "context"
+    "sort"
+)
+
+// GrowthRate is how much total experience a species needs for each level.
+type GrowthRate struct {
+    Name   string
+    Levels []GrowthLevel
+}
+
+type GrowthLevel struct {
+    Level      int
+    Experience int
+}
+
+type growthRateResponse struct {
+    Name   string `json:"name"`
+    Levels []struct {
+        Level      int `json:"level"`
+        Experience int `json:"experience"`
+    } `json:"levels"`
+}
+
+func (c *Client) FetchGrowthRate(ctx context.Context, name string) (*GrowthRate, error) {
+    r, err := fetchJSON[growthRateResponse](ctx, c, c.baseURL+"growth-rate/"+name+"/")
+    if err != nil {
+        return nil, err
+    }
+    g := &GrowthRate{Name: r.Name}
+    for _, l := range r.Levels {
+        g.Levels = append(g.Levels, GrowthLevel{Level: l.Level, Experience: l.Experience})
+    }
+    sort.Slice(g.Levels, func(i, j int) bool { return g.Levels[i].Level < g.Levels[j].Level })
+    return g, nil
+}
+
+// Experience is the total experience needed to reach level.
+func (g *GrowthRate) Experience(level int) int {
+    xp := 0
+    for _, l := range g.Levels {
+        if l.Level > level {
+            break
+        }
+        xp = l.Experience
+    }
+    return xp
+}
+
+// Level is the highest level reached with xp total experience.
+func (g *GrowthRate) Level(xp int) int {
+    level := 1
+    for _, l := range g.Levels {
+        if l.Experience > xp {
+            break
+        }
+        level = l.Level
+    }
+    return level
+}
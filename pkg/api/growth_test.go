@@ -0,0 +1,41 @@
+package api
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestFetchGrowthRate(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/growth-rate/medium/" {
+            http.NotFound(w, r)
+            return
+        }
+        w.Write([]byte(`{"name": "medium", "levels": [
+  {"level": 3, "experience": 27},
+  {"level": 1, "experience": 0},
+  {"level": 2, "experience": 8},
+  {"level": 4, "experience": 64}
+]}`))
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    g, err := c.FetchGrowthRate(context.Background(), "medium")
+    if err != nil {
+        t.Fatalf("FetchGrowthRate error: %v", err)
+    }
+    if g.Name != "medium" || len(g.Levels) != 4 {
+        t.Fatalf("unexpected growth rate %+v", g)
+    }
+    if got := g.Experience(3); got != 27 {
+        t.Fatalf("expected 27 experience for level 3, got %d", got)
+    }
+    for xp, want := range map[int]int{0: 1, 7: 1, 8: 2, 63: 3, 64: 4, 1000: 4} {
+        if got := g.Level(xp); got != want {
+            t.Errorf("Level(%d) = %d, want %d", xp, got, want)
+        }
+    }
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+    "context"
+    "strconv"
+    "strings"
+)
+
+type PokemonMove struct {
+    Name         string `json:"name"`
+    LearnMethod  string `json:"learn_method"`
+    LevelLearned int    `json:"level_learned"`
+    VersionGroup string `json:"version_group"`
+}
+
+type Move struct {
+    Name        string
+    Power       int
+    Accuracy    int
+    PP          int
+    Type        string
+    DamageClass string
+    Priority    int
+    Effect      string
+    Target      string
+    Category    string
+
+    Ailment       string
+    AilmentChance int
+    StatChanges   []MoveStatChange
+    StatChance    int
+}
+
+type MoveStatChange struct {
+    Stat   string
+    Change int
+}
+
+type pokemonMoveResponse struct {
+    Move                namedAPIResource `json:"move"`
+    VersionGroupDetails []struct {
+        LevelLearnedAt  int              `json:"level_learned_at"`
+        MoveLearnMethod namedAPIResource `json:"move_learn_method"`
+        VersionGroup    namedAPIResource `json:"version_group"`
+    } `json:"version_group_details"`
+}
+
+type moveResponse struct {
+    Name         string           `json:"name"`
+    Power        *int             `json:"power"`
+    Accuracy     *int             `json:"accuracy"`
+    PP           *int             `json:"pp"`
+    Priority     int              `json:"priority"`
+    EffectChance *int             `json:"effect_chance"`
+    Type         namedAPIResource `json:"type"`
+    DamageClass  namedAPIResource `json:"damage_class"`
+    Target       namedAPIResource `json:"target"`
+    EffectEntries []struct {
+        ShortEffect string           `json:"short_effect"`
+        Language    namedAPIResource `json:"language"`
+    } `json:"effect_entries"`
+    Meta *struct {
+        Ailment       namedAPIResource `json:"ailment"`
+        AilmentChance int              `json:"ailment_chance"`
+        Category      namedAPIResource `json:"category"`
+        StatChance    int              `json:"stat_chance"`
+    } `json:"meta"`
+    StatChanges []struct {
+        Change int              `json:"change"`
+        Stat   namedAPIResource `json:"stat"`
+    } `json:"stat_changes"`
+}
+
+func (r *moveResponse) toMove() *Move {
+    m := &Move{
+        Name:        r.Name,
+        Priority:    r.Priority,
+        Type:        r.Type.Name,
+        DamageClass: r.DamageClass.Name,
+        Target:      r.Target.Name,
+    }
+    if r.Meta != nil {
+        if r.Meta.Ailment.Name != "none" {
+            m.Ailment = r.Meta.Ailment.Name
+        }
+        m.AilmentChance = r.Meta.AilmentChance
+        m.Category = r.Meta.Category.Name
+        m.StatChance = r.Meta.StatChance
+    }
+    for _, sc := range r.StatChanges {
+        m.StatChanges = append(m.StatChanges, MoveStatChange{Stat: sc.Stat.Name, Change: sc.Change})
+    }
+    if r.Power != nil {
+        m.Power = *r.Power
+    }
+    if r.Accuracy != nil {
+        m.Accuracy = *r.Accuracy
+    }
+    if r.PP != nil {
+        m.PP = *r.PP
+    }
+    for _, e := range r.EffectEntries {
+        if e.Language.Name != "en" {
+            continue
+        }
+        m.Effect = cleanFlavorText(e.ShortEffect)
+        if r.EffectChance != nil {
+            m.Effect = strings.ReplaceAll(m.Effect, "$effect_chance", strconv.Itoa(*r.EffectChance))
+        }
+        break
+    }
+    return m
+}
+
+func (c *Client) FetchMove(ctx context.Context, name string) (*Move, error) {
+    r, err := fetchJSON[moveResponse](ctx, c, c.baseURL+"move/"+name+"/")
+    if err != nil {
+        return nil, err
+    }
+    return r.toMove(), nil
+}
+
+func (p Pokemon) MovesFor(versionGroup string) []PokemonMove {
+    var moves []PokemonMove
+    for _, m := range p.Moves {
+        if versionGroup == "" || m.VersionGroup == versionGroup {
+            moves = append(moves, m)
+        }
+    }
+    return moves
+}
+
+func (p Pokemon) VersionGroups() []string {
+    seen := make(map[string]bool)
+    var groups []string
+    for _, m := range p.Moves {
+        if !seen[m.VersionGroup] {
+            seen[m.VersionGroup] = true
+            groups = append(groups, m.VersionGroup)
+        }
+    }
+    return groups
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestFetchMove(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/move/thunderbolt/":
+            w.Write([]byte(`{
+  "name": "thunderbolt",
+  "power": 90,
+  "accuracy": 100,
+  "pp": 15,
+  "priority": 0,
+  "effect_chance": 10,
+  "type": {"name": "electric"},
+  "damage_class": {"name": "special"},
+  "target": {"name": "selected-pokemon"},
+  "meta": {"ailment": {"name": "paralysis"}, "ailment_chance": 10, "category": {"name": "damage+ailment"}, "stat_chance": 0},
+  "stat_changes": [],
+  "effect_entries": [
+    {"short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en"}}
+  ]
+}`))
+        case "/move/swords-dance/":
+            w.Write([]byte(`{"name": "swords-dance", "power": null, "accuracy": null, "pp": 20, "type": {"name": "normal"}, "damage_class": {"name": "status"}, "target": {"name": "user"}, "meta": {"ailment": {"name": "none"}, "ailment_chance": 0, "category": {"name": "net-good-stats"}, "stat_chance": 0}, "stat_changes": [{"change": 2, "stat": {"name": "attack"}}]}`))
+        default:
+            http.NotFound(w, r)
+        }
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    m, err := c.FetchMove(context.Background(), "thunderbolt")
+    if err != nil {
+        t.Fatalf("FetchMove error: %v", err)
+    }
+    if m.Power != 90 || m.Accuracy != 100 || m.PP != 15 || m.Type != "electric" || m.DamageClass != "special" {
+        t.Fatalf("unexpected move: %+v", m)
+    }
+    if m.Effect != "Has a 10% chance to paralyze the target." {
+        t.Fatalf("unexpected effect %q", m.Effect)
+    }
+    if m.Ailment != "paralysis" || m.AilmentChance != 10 || m.Category != "damage+ailment" || len(m.StatChanges) != 0 {
+        t.Fatalf("unexpected move meta: %+v", m)
+    }
+
+    m, err = c.FetchMove(context.Background(), "swords-dance")
+    if err != nil {
+        t.Fatalf("FetchMove error: %v", err)
+    }
+    if m.Power != 0 || m.Accuracy != 0 || m.DamageClass != "status" {
+        t.Fatalf("expected null power/accuracy to decode as zero: %+v", m)
+    }
+    if m.Ailment != "" || m.Target != "user" || len(m.StatChanges) != 1 || m.StatChanges[0] != (MoveStatChange{Stat: "attack", Change: 2}) {
+        t.Fatalf("unexpected stat changes: %+v", m)
+    }
+}
+
+func TestFetchPokemonMoves(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte(`{
+  "name": "pikachu",
+  "moves": [
+    {"move": {"name": "thunder-shock"}, "version_group_details": [
+      {"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+      {"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "yellow"}}
+    ]},
+    {"move": {"name": "mega-punch"}, "version_group_details": [
+      {"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}}
+    ]}
+  ]
+}`))
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    p, err := c.FetchPokemon(context.Background(), "pikachu")
+    if err != nil {
+        t.Fatalf("FetchPokemon error: %v", err)
+    }
+    if len(p.Moves) != 3 {
+        t.Fatalf("expected 3 flattened move entries, got %d", len(p.Moves))
+    }
+    redBlue := p.MovesFor("red-blue")
+    if len(redBlue) != 2 || redBlue[1].Name != "mega-punch" || redBlue[1].LearnMethod != "machine" {
+        t.Fatalf("unexpected red-blue moves: %+v", redBlue)
+    }
+    if groups := p.VersionGroups(); len(groups) != 2 || groups[0] != "red-blue" || groups[1] != "yellow" {
+        t.Fatalf("unexpected version groups: %v", groups)
+    }
+}
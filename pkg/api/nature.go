@@ -0,0 +1,59 @@
+package api
+
+import "context"
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral natures
+// leave both empty.
+type Nature struct {
+    Name          string `json:"name"`
+    IncreasedStat string `json:"increased_stat,omitempty"`
+    DecreasedStat string `json:"decreased_stat,omitempty"`
+}
+
+// Multiplier is how the nature scales stat.
+func (n Nature) Multiplier(stat string) float64 {
+    if stat == "" || n.IncreasedStat == n.DecreasedStat {
+        return 1
+    }
+    switch stat {
+    case n.IncreasedStat:
+        return 1.1
+    case n.DecreasedStat:
+        return 0.9
+    }
+    return 1
+}
+
+type natureResponse struct {
+    Name          string            `json:"name"`
+    IncreasedStat *namedAPIResource `json:"increased_stat"`
+    DecreasedStat *namedAPIResource `json:"decreased_stat"`
+}
+
+type namedAPIResourceList struct {
+    Results []namedAPIResource `json:"results"`
+}
+
+// FetchNatureNames lists every nature.
+func (c *Client) FetchNatureNames(ctx context.Context) ([]string, error) {
+    r, err := fetchJSON[namedAPIResourceList](ctx, c, c.baseURL+"nature/?limit=100")
+    if err != nil {
+        return nil, err
+    }
+    return names(r.Results), nil
+}
+
+func (c *Client) FetchNature(ctx context.Context, name string) (*Nature, error) {
+    r, err := fetchJSON[natureResponse](ctx, c, c.baseURL+"nature/"+name+"/")
+    if err != nil {
+        return nil, err
+    }
+    n := &Nature{Name: r.Name}
+    if r.IncreasedStat != nil {
+        n.IncreasedStat = r.IncreasedStat.Name
+    }
+    if r.DecreasedStat != nil {
+        n.DecreasedStat = r.DecreasedStat.Name
+    }
+    return n, nil
+}
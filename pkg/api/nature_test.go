@@ -0,0 +1,46 @@
+package api
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestFetchNature(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/nature/":
+            w.Write([]byte(`{"count": 2, "results": [{"name": "hardy"}, {"name": "adamant"}]}`))
+        case "/nature/adamant/":
+            w.Write([]byte(`{"name": "adamant", "increased_stat": {"name": "attack"}, "decreased_stat": {"name": "special-attack"}}`))
+        case "/nature/hardy/":
+            w.Write([]byte(`{"name": "hardy", "increased_stat": null, "decreased_stat": null}`))
+        default:
+            http.NotFound(w, r)
+        }
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    names, err := c.FetchNatureNames(context.Background())
+    if err != nil || len(names) != 2 || names[1] != "adamant" {
+        t.Fatalf("unexpected nature names %v (err %v)", names, err)
+    }
+
+    n, err := c.FetchNature(context.Background(), "adamant")
+    if err != nil {
+        t.Fatalf("FetchNature error: %v", err)
+    }
+    if n.Multiplier("attack") != 1.1 || n.Multiplier("special-attack") != 0.9 || n.Multiplier("speed") != 1 {
+        t.Fatalf("unexpected multipliers for %+v", n)
+    }
+
+    hardy, err := c.FetchNature(context.Background(), "hardy")
+    if err != nil {
+        t.Fatalf("FetchNature error: %v", err)
+    }
+    if hardy.IncreasedStat != "" || hardy.Multiplier("attack") != 1 {
+        t.Fatalf("expected a neutral nature, got %+v", hardy)
+    }
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+const DefaultRequestsPerSecond = 10
+const DefaultBurst = 20
+const DefaultMaxConcurrent = 4
+
+type limiter struct {
+    mu     sync.Mutex
+    rate   float64
+    burst  float64
+    tokens float64
+    last   time.Time
+}
+
+func newLimiter(rps float64, burst int) *limiter {
+    if rps <= 0 {
+        return nil
+    }
+    if burst < 1 {
+        burst = 1
+    }
+    return &limiter{rate: rps, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (l *limiter) Wait(ctx context.Context) error {
+    if l == nil {
+        return ctx.Err()
+    }
+    for {
+        l.mu.Lock()
+        now := time.Now()
+        l.tokens += now.Sub(l.last).Seconds() * l.rate
+        if l.tokens > l.burst {
+            l.tokens = l.burst
+        }
+        l.last = now
+        if l.tokens >= 1 {
+            l.tokens--
+            l.mu.Unlock()
+            return nil
+        }
+        wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+        l.mu.Unlock()
+
+        if err := sleep(ctx, wait); err != nil {
+            return err
+        }
+    }
+}
+
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+    if n <= 0 {
+        return nil
+    }
+    return make(semaphore, n)
+}
+
+func (s semaphore) Acquire(ctx context.Context) error {
+    if s == nil {
+        return ctx.Err()
+    }
+    select {
+    case s <- struct{}{}:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+func (s semaphore) Release() {
+    if s != nil {
+        <-s
+    }
+}
+
+func WithRateLimit(rps float64, burst int) Option {
+    return func(c *Client) { c.limiter = newLimiter(rps, burst) }
+}
+
+func WithMaxConcurrent(n int) Option {
+    return func(c *Client) { c.inFlight = newSemaphore(n) }
+}
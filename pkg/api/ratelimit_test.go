@@ -0,0 +1,76 @@
+package api
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestRateLimitThrottlesRequests(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte(`{"count": 0, "results": []}`))
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL), WithRateLimit(50, 1))
+
+    start := time.Now()
+    for i := 0; i < 5; i++ {
+        if _, err := c.FetchLocationAreas(context.Background(), c.LocationAreasURL()); err != nil {
+            t.Fatalf("fetch %d error: %v", i, err)
+        }
+    }
+    if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+        t.Fatalf("expected 5 requests at 50/s to take at least 70ms, took %s", elapsed)
+    }
+}
+
+func TestRateLimitWaitHonorsContext(t *testing.T) {
+    l := newLimiter(0.001, 1)
+    if err := l.Wait(context.Background()); err != nil {
+        t.Fatalf("expected burst token to be available, got %v", err)
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+    defer cancel()
+    if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("expected deadline exceeded, got %v", err)
+    }
+}
+
+func TestMaxConcurrentCapsInFlightRequests(t *testing.T) {
+    var current, peak int32
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        n := atomic.AddInt32(&current, 1)
+        for {
+            p := atomic.LoadInt32(&peak)
+            if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+                break
+            }
+        }
+        time.Sleep(20 * time.Millisecond)
+        atomic.AddInt32(&current, -1)
+        w.Write([]byte(`{"count": 0, "results": []}`))
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL), WithRateLimit(0, 0), WithMaxConcurrent(2))
+
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if _, err := c.FetchLocationAreas(context.Background(), c.LocationAreasURL()); err != nil {
+                t.Errorf("fetch error: %v", err)
+            }
+        }()
+    }
+    wg.Wait()
+
+    if peak > 2 {
+        t.Fatalf("expected at most 2 concurrent requests, saw %d", peak)
+    }
+}
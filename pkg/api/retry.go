@@ -0,0 +1,131 @@
+package api
+
+import (
+    "context"
+    "errors"
+    "io"
+    "math/rand"
+    "net"
+    "net/http"
+    "time"
+)
+
+type RetryPolicy struct {
+    MaxAttempts     int
+    BaseDelay       time.Duration
+    MaxDelay        time.Duration
+    Jitter          float64
+    RetryableStatus map[int]bool
+}
+
+type RetryHook func(attempt, maxAttempts int, err error)
+
+func DefaultRetryPolicy() RetryPolicy {
+    return RetryPolicy{
+        MaxAttempts: 4,
+        BaseDelay:   250 * time.Millisecond,
+        MaxDelay:    5 * time.Second,
+        Jitter:      0.2,
+        RetryableStatus: map[int]bool{
+            http.StatusRequestTimeout:      true,
+            http.StatusTooManyRequests:     true,
+            http.StatusInternalServerError: true,
+            http.StatusBadGateway:          true,
+            http.StatusServiceUnavailable:  true,
+            http.StatusGatewayTimeout:      true,
+        },
+    }
+}
+
+func WithRetryPolicy(p RetryPolicy) Option {
+    return func(c *Client) { c.retry = p }
+}
+
+func WithRetryHook(h RetryHook) Option {
+    return func(c *Client) { c.onRetry = h }
+}
+
+func (p RetryPolicy) attempts() int {
+    if p.MaxAttempts < 1 {
+        return 1
+    }
+    return p.MaxAttempts
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+    if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+        return false
+    }
+    var rateLimited *RateLimitError
+    var serverErr *ServerError
+    var statusErr *StatusError
+    var netErr net.Error
+    switch {
+    case errors.As(err, &rateLimited):
+        // Waiting longer than MaxDelay would only stall the caller; let it
+        // report when to try again instead.
+        if p.MaxDelay > 0 && rateLimited.RetryAfter > p.MaxDelay {
+            return false
+        }
+        return p.RetryableStatus[http.StatusTooManyRequests]
+    case errors.As(err, &serverErr):
+        return p.RetryableStatus[serverErr.StatusCode]
+    case errors.As(err, &statusErr):
+        return p.RetryableStatus[statusErr.StatusCode]
+    case errors.Is(err, ErrNotFound):
+        return false
+    case errors.As(err, &netErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+        return true
+    }
+    return false
+}
+
+// delay returns how long to wait before the given retry (1 for the first
+// retry). jitter is a uniform sample in [0, 1).
+func (p RetryPolicy) delay(retry int, err error, jitter float64) time.Duration {
+    d := p.BaseDelay
+    for i := 1; i < retry && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+        d *= 2
+    }
+    if p.MaxDelay > 0 && d > p.MaxDelay {
+        d = p.MaxDelay
+    }
+    if p.Jitter > 0 {
+        d = time.Duration(float64(d) * (1 - p.Jitter + 2*p.Jitter*jitter))
+    }
+    var rateLimited *RateLimitError
+    if errors.As(err, &rateLimited) && rateLimited.RetryAfter > d {
+        d = rateLimited.RetryAfter
+    }
+    return d
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+    attempts := c.retry.attempts()
+    for attempt := 1; ; attempt++ {
+        body, err := c.do(ctx, url)
+        if err == nil || attempt >= attempts || !c.retry.retryable(err) {
+            return body, err
+        }
+        if c.onRetry != nil {
+            c.onRetry(attempt+1, attempts, err)
+        }
+        if err := sleep(ctx, c.retry.delay(attempt, err, rand.Float64())); err != nil {
+            return nil, err
+        }
+    }
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+    if d <= 0 {
+        return ctx.Err()
+    }
+    t := time.NewTimer(d)
+    defer t.Stop()
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-t.C:
+        return nil
+    }
+}
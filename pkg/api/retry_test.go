@@ -0,0 +1,135 @@
+package api
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+    p := DefaultRetryPolicy()
+    p.BaseDelay = time.Millisecond
+    p.MaxDelay = 5 * time.Millisecond
+    return p
+}
+
+func flakyServer(failures int32, status int) (*httptest.Server, *int32) {
+    var calls int32
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if atomic.AddInt32(&calls, 1) <= failures {
+            w.WriteHeader(status)
+            return
+        }
+        w.Write([]byte(`{"name": "area-1", "pokemon_encounters": []}`))
+    }))
+    return ts, &calls
+}
+
+func TestRetryRecoversFromTransientFailures(t *testing.T) {
+    ts, calls := flakyServer(2, http.StatusBadGateway)
+    defer ts.Close()
+
+    var seen []int
+    c := NewClient(
+        WithBaseURL(ts.URL),
+        WithRetryPolicy(fastRetryPolicy()),
+        WithRetryHook(func(attempt, max int, err error) {
+            if max != 4 {
+                t.Errorf("expected max attempts 4, got %d", max)
+            }
+            seen = append(seen, attempt)
+        }),
+    )
+
+    detail, err := c.FetchLocationAreaDetail(context.Background(), c.LocationAreaURL("area-1"))
+    if err != nil {
+        t.Fatalf("expected success after retries, got %v", err)
+    }
+    if detail.Name != "area-1" {
+        t.Fatalf("expected area-1, got %q", detail.Name)
+    }
+    if *calls != 3 {
+        t.Fatalf("expected 3 calls, got %d", *calls)
+    }
+    if len(seen) != 2 || seen[0] != 2 || seen[1] != 3 {
+        t.Fatalf("expected retry hook for attempts [2 3], got %v", seen)
+    }
+}
+
+func TestRetryGivesUp(t *testing.T) {
+    ts, calls := flakyServer(10, http.StatusServiceUnavailable)
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL), WithRetryPolicy(fastRetryPolicy()))
+
+    _, err := c.FetchLocationAreaDetail(context.Background(), c.LocationAreaURL("area-1"))
+    var se *ServerError
+    if !errors.As(err, &se) {
+        t.Fatalf("expected *ServerError after exhausting retries, got %v", err)
+    }
+    if *calls != 4 {
+        t.Fatalf("expected 4 calls, got %d", *calls)
+    }
+}
+
+func TestRetrySkipsNonRetryable(t *testing.T) {
+    ts, calls := flakyServer(10, http.StatusNotFound)
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL), WithRetryPolicy(fastRetryPolicy()))
+
+    _, err := c.FetchLocationAreaDetail(context.Background(), c.LocationAreaURL("area-1"))
+    if !errors.Is(err, ErrNotFound) {
+        t.Fatalf("expected ErrNotFound, got %v", err)
+    }
+    if *calls != 1 {
+        t.Fatalf("expected a single call, got %d", *calls)
+    }
+}
+
+func TestRetryStopsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+    var calls int32
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        atomic.AddInt32(&calls, 1)
+        w.Header().Set("Retry-After", "60")
+        w.WriteHeader(http.StatusTooManyRequests)
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL), WithRetryPolicy(fastRetryPolicy()))
+
+    _, err := c.FetchLocationAreaDetail(context.Background(), c.LocationAreaURL("area-1"))
+    var rl *RateLimitError
+    if !errors.As(err, &rl) || rl.RetryAfter != time.Minute {
+        t.Fatalf("expected *RateLimitError with the server's wait, got %v", err)
+    }
+    if calls != 1 {
+        t.Fatalf("expected a single call, got %d", calls)
+    }
+}
+
+func TestRetryDelay(t *testing.T) {
+    p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+    cases := []struct {
+        retry    int
+        err      error
+        expected time.Duration
+    }{
+        {retry: 1, err: &ServerError{StatusCode: 502}, expected: 100 * time.Millisecond},
+        {retry: 2, err: &ServerError{StatusCode: 502}, expected: 200 * time.Millisecond},
+        {retry: 3, err: &ServerError{StatusCode: 502}, expected: 400 * time.Millisecond},
+        {retry: 10, err: &ServerError{StatusCode: 502}, expected: time.Second},
+        {retry: 1, err: &RateLimitError{RetryAfter: 800 * time.Millisecond}, expected: 800 * time.Millisecond},
+    }
+    for _, c := range cases {
+        if got := p.delay(c.retry, c.err, 0.5); got != c.expected {
+            t.Errorf("retry %d: expected %s, got %s", c.retry, c.expected, got)
+        }
+    }
+
+    p.Jitter = 0.5
+    if got := p.delay(1, nil, 0); got != 50*time.Millisecond {
+        t.Errorf("expected lower jitter bound 50ms, got %s", got)
+    }
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+    "context"
+    "strings"
+)
+
+type namedAPIResource struct {
+    Name string `json:"name"`
+    URL  string `json:"url"`
+}
+
+type PokemonSpecies struct {
+    Name              string
+    CaptureRate       int
+    BaseHappiness     int
+    IsLegendary       bool
+    IsMythical        bool
+    Genera            []Genus
+    FlavorTextEntries []FlavorTextEntry
+    EvolutionChainURL string
+    GrowthRate        string
+    EggGroups         []string
+    Habitat           string
+    Color             string
+}
+
+type Genus struct {
+    Genus    string
+    Language string
+}
+
+type FlavorTextEntry struct {
+    Text     string
+    Language string
+    Version  string
+}
+
+type speciesResponse struct {
+    Name          string `json:"name"`
+    CaptureRate   int    `json:"capture_rate"`
+    BaseHappiness int    `json:"base_happiness"`
+    IsLegendary   bool   `json:"is_legendary"`
+    IsMythical    bool   `json:"is_mythical"`
+    Genera []struct {
+        Genus    string           `json:"genus"`
+        Language namedAPIResource `json:"language"`
+    } `json:"genera"`
+    FlavorTextEntries []struct {
+        FlavorText string           `json:"flavor_text"`
+        Language   namedAPIResource `json:"language"`
+        Version    namedAPIResource `json:"version"`
+    } `json:"flavor_text_entries"`
+    EvolutionChain struct {
+        URL string `json:"url"`
+    } `json:"evolution_chain"`
+    GrowthRate namedAPIResource   `json:"growth_rate"`
+    EggGroups  []namedAPIResource `json:"egg_groups"`
+    Habitat    *namedAPIResource  `json:"habitat"`
+    Color      namedAPIResource   `json:"color"`
+}
+
+func (r *speciesResponse) toSpecies() *PokemonSpecies {
+    s := &PokemonSpecies{
+        Name:              r.Name,
+        CaptureRate:       r.CaptureRate,
+        BaseHappiness:     r.BaseHappiness,
+        IsLegendary:       r.IsLegendary,
+        IsMythical:        r.IsMythical,
+        EvolutionChainURL: r.EvolutionChain.URL,
+        GrowthRate:        r.GrowthRate.Name,
+        Color:             r.Color.Name,
+    }
+    for _, g := range r.Genera {
+        s.Genera = append(s.Genera, Genus{Genus: g.Genus, Language: g.Language.Name})
+    }
+    for _, f := range r.FlavorTextEntries {
+        s.FlavorTextEntries = append(s.FlavorTextEntries, FlavorTextEntry{
+            Text:     cleanFlavorText(f.FlavorText),
+            Language: f.Language.Name,
+            Version:  f.Version.Name,
+        })
+    }
+    for _, e := range r.EggGroups {
+        s.EggGroups = append(s.EggGroups, e.Name)
+    }
+    if r.Habitat != nil {
+        s.Habitat = r.Habitat.Name
+    }
+    return s
+}
+
+func cleanFlavorText(text string) string {
+    return strings.Join(strings.Fields(text), " ")
+}
+
+func (c *Client) FetchPokemonSpecies(ctx context.Context, name string) (*PokemonSpecies, error) {
+    r, err := fetchJSON[speciesResponse](ctx, c, c.baseURL+"pokemon-species/"+name+"/")
+    if err != nil {
+        return nil, err
+    }
+    return r.toSpecies(), nil
+}
+
+func (s *PokemonSpecies) Genus(lang string) string {
+    for _, g := range s.Genera {
+        if g.Language == lang {
+            return g.Genus
+        }
+    }
+    return ""
+}
+
+// FlavorText returns the most recent Pokédex entry in the given language.
+func (s *PokemonSpecies) FlavorText(lang string) string {
+    for i := len(s.FlavorTextEntries) - 1; i >= 0; i-- {
+        if s.FlavorTextEntries[i].Language == lang {
+            return s.FlavorTextEntries[i].Text
+        }
+    }
+    return ""
+}
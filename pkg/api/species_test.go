@@ -0,0 +1,65 @@
+package api
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+const pikachuSpeciesJSON = `{
+  "name": "pikachu",
+  "capture_rate": 190,
+  "base_happiness": 50,
+  "is_legendary": false,
+  "is_mythical": false,
+  "genera": [
+    {"genus": "ねずみポケモン", "language": {"name": "ja"}},
+    {"genus": "Mouse Pokémon", "language": {"name": "en"}}
+  ],
+  "flavor_text_entries": [
+    {"flavor_text": "When several of\nthese POKéMON gather,", "language": {"name": "en"}, "version": {"name": "red"}},
+    {"flavor_text": "It keeps its tail\fraised to monitor\nits surroundings.", "language": {"name": "en"}, "version": {"name": "yellow"}},
+    {"flavor_text": "Quand plusieurs de ces Pokémon", "language": {"name": "fr"}, "version": {"name": "x"}}
+  ],
+  "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"},
+  "growth_rate": {"name": "medium"},
+  "egg_groups": [{"name": "ground"}, {"name": "fairy"}],
+  "habitat": {"name": "forest"},
+  "color": {"name": "yellow"}
+}`
+
+func TestFetchPokemonSpecies(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path == "/pokemon-species/pikachu/" {
+            w.Write([]byte(pikachuSpeciesJSON))
+            return
+        }
+        http.NotFound(w, r)
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    s, err := c.FetchPokemonSpecies(context.Background(), "pikachu")
+    if err != nil {
+        t.Fatalf("FetchPokemonSpecies error: %v", err)
+    }
+    if s.CaptureRate != 190 || s.BaseHappiness != 50 {
+        t.Fatalf("unexpected capture rate/happiness: %+v", s)
+    }
+    if s.GrowthRate != "medium" || s.Habitat != "forest" || s.Color != "yellow" {
+        t.Fatalf("unexpected growth rate/habitat/color: %+v", s)
+    }
+    if len(s.EggGroups) != 2 || s.EggGroups[1] != "fairy" {
+        t.Fatalf("unexpected egg groups: %v", s.EggGroups)
+    }
+    if s.EvolutionChainURL != "https://pokeapi.co/api/v2/evolution-chain/10/" {
+        t.Fatalf("unexpected evolution chain url %q", s.EvolutionChainURL)
+    }
+    if got := s.Genus("en"); got != "Mouse Pokémon" {
+        t.Fatalf("expected english genus, got %q", got)
+    }
+    if got := s.FlavorText("en"); got != "It keeps its tail raised to monitor its surroundings." {
+        t.Fatalf("unexpected flavor text %q", got)
+    }
+}
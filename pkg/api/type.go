@@ -0,0 +1,115 @@
+package api
+
+import (
+    "context"
+    "sync"
+)
+
+type Type struct {
+    Name            string
+    DamageRelations DamageRelations
+}
+
+type DamageRelations struct {
+    DoubleDamageTo   []string
+    HalfDamageTo     []string
+    NoDamageTo       []string
+    DoubleDamageFrom []string
+    HalfDamageFrom   []string
+    NoDamageFrom     []string
+}
+
+type typeResponse struct {
+    Name            string `json:"name"`
+    DamageRelations struct {
+        DoubleDamageTo   []namedAPIResource `json:"double_damage_to"`
+        HalfDamageTo     []namedAPIResource `json:"half_damage_to"`
+        NoDamageTo       []namedAPIResource `json:"no_damage_to"`
+        DoubleDamageFrom []namedAPIResource `json:"double_damage_from"`
+        HalfDamageFrom   []namedAPIResource `json:"half_damage_from"`
+        NoDamageFrom     []namedAPIResource `json:"no_damage_from"`
+    } `json:"damage_relations"`
+}
+
+func names(rs []namedAPIResource) []string {
+    out := make([]string, 0, len(rs))
+    for _, r := range rs {
+        out = append(out, r.Name)
+    }
+    return out
+}
+
+func (c *Client) FetchType(ctx context.Context, name string) (*Type, error) {
+    r, err := fetchJSON[typeResponse](ctx, c, c.baseURL+"type/"+name+"/")
+    if err != nil {
+        return nil, err
+    }
+    dr := r.DamageRelations
+    return &Type{
+        Name: r.Name,
+        DamageRelations: DamageRelations{
+            DoubleDamageTo:   names(dr.DoubleDamageTo),
+            HalfDamageTo:     names(dr.HalfDamageTo),
+            NoDamageTo:       names(dr.NoDamageTo),
+            DoubleDamageFrom: names(dr.DoubleDamageFrom),
+            HalfDamageFrom:   names(dr.HalfDamageFrom),
+            NoDamageFrom:     names(dr.NoDamageFrom),
+        },
+    }, nil
+}
+
+// TypeChart holds attacking-type multipliers built from the damage relations
+// of every type added to it. Unknown matchups are neutral.
+type TypeChart struct {
+    mu     sync.Mutex
+    loaded map[string]bool
+    chart  map[string]map[string]float64
+}
+
+func NewTypeChart() *TypeChart {
+    return &TypeChart{loaded: make(map[string]bool), chart: make(map[string]map[string]float64)}
+}
+
+func (tc *TypeChart) Add(t *Type) {
+    tc.mu.Lock()
+    defer tc.mu.Unlock()
+    tc.loaded[t.Name] = true
+    dr := t.DamageRelations
+    tc.set(t.Name, dr.DoubleDamageTo, 2, true)
+    tc.set(t.Name, dr.HalfDamageTo, 0.5, true)
+    tc.set(t.Name, dr.NoDamageTo, 0, true)
+    tc.set(t.Name, dr.DoubleDamageFrom, 2, false)
+    tc.set(t.Name, dr.HalfDamageFrom, 0.5, false)
+    tc.set(t.Name, dr.NoDamageFrom, 0, false)
+}
+
+func (tc *TypeChart) set(name string, others []string, mult float64, attacking bool) {
+    for _, other := range others {
+        atk, def := name, other
+        if !attacking {
+            atk, def = other, name
+        }
+        if tc.chart[atk] == nil {
+            tc.chart[atk] = make(map[string]float64)
+        }
+        tc.chart[atk][def] = mult
+    }
+}
+
+func (tc *TypeChart) Has(name string) bool {
+    tc.mu.Lock()
+    defer tc.mu.Unlock()
+    return tc.loaded[name]
+}
+
+func (tc *TypeChart) Multiplier(attacking string, defending []string) float64 {
+    tc.mu.Lock()
+    defer tc.mu.Unlock()
+    mult := 1.0
+    for _, d := range defending {
+        if m, ok := tc.chart[attacking][d]; ok {
+            mult *= m
+        }
+    }
+    return mult
+}
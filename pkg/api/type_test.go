@@ -0,0 +1,65 @@
+package api
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestFetchTypeAndChart(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/type/water/":
+            w.Write([]byte(`{"name": "water", "damage_relations": {
+  "double_damage_to": [{"name": "fire"}, {"name": "ground"}, {"name": "rock"}],
+  "half_damage_to": [{"name": "water"}, {"name": "grass"}, {"name": "dragon"}],
+  "no_damage_to": [],
+  "double_damage_from": [{"name": "grass"}, {"name": "electric"}],
+  "half_damage_from": [{"name": "fire"}, {"name": "water"}],
+  "no_damage_from": []
+}}`))
+        case "/type/ghost/":
+            w.Write([]byte(`{"name": "ghost", "damage_relations": {
+  "no_damage_to": [{"name": "normal"}],
+  "no_damage_from": [{"name": "normal"}, {"name": "fighting"}]
+}}`))
+        default:
+            http.NotFound(w, r)
+        }
+    }))
+    defer ts.Close()
+    c := NewClient(WithBaseURL(ts.URL))
+
+    chart := NewTypeChart()
+    for _, name := range []string{"water", "ghost"} {
+        typ, err := c.FetchType(context.Background(), name)
+        if err != nil {
+            t.Fatalf("FetchType(%s) error: %v", name, err)
+        }
+        chart.Add(typ)
+    }
+
+    cases := []struct {
+        attacking string
+        defending []string
+        expected  float64
+    }{
+        {attacking: "water", defending: []string{"fire"}, expected: 2},
+        {attacking: "water", defending: []string{"ground", "rock"}, expected: 4},
+        {attacking: "water", defending: []string{"grass", "fire"}, expected: 1},
+        {attacking: "water", defending: []string{"dragon"}, expected: 0.5},
+        {attacking: "electric", defending: []string{"water"}, expected: 2},
+        {attacking: "normal", defending: []string{"ghost"}, expected: 0},
+        {attacking: "ghost", defending: []string{"normal"}, expected: 0},
+        {attacking: "fairy", defending: []string{"steel"}, expected: 1},
+    }
+    for _, tc := range cases {
+        if got := chart.Multiplier(tc.attacking, tc.defending); got != tc.expected {
+            t.Errorf("%s vs %v: expected %v, got %v", tc.attacking, tc.defending, tc.expected, got)
+        }
+    }
+    if !chart.Has("water") || chart.Has("fire") {
+        t.Fatalf("unexpected loaded types")
+    }
+}
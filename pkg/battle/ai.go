@@ -0,0 +1,239 @@
+package battle
+
+import (
+    "fmt"
+    "math/rand"
+    "sort"
+)
+
+const DefaultAI = "random"
+
+// BattleAI is a Controller with a name that can be selected from the CLI.
+// Strategies only draw randomness from the rng they were built with.
+type BattleAI interface {
+    Controller
+    Name() string
+}
+
+var strategies = map[string]func(rng *rand.Rand) BattleAI{
+    "random":    func(rng *rand.Rand) BattleAI { return RandomAI{Rng: rng} },
+    "greedy":    func(rng *rand.Rand) BattleAI { return GreedyAI{} },
+    "typeaware": func(rng *rand.Rand) BattleAI { return TypeAwareAI{} },
+    "minimax":   func(rng *rand.Rand) BattleAI { return MinimaxAI{} },
+}
+
+func NewAI(name string, rng *rand.Rand) (BattleAI, error) {
+    f, ok := strategies[name]
+    if !ok {
+        return nil, fmt.Errorf("unknown AI %q", name)
+    }
+    return f(rng), nil
+}
+
+func AINames() []string {
+    names := make([]string, 0, len(strategies))
+    for n := range strategies {
+        names = append(names, n)
+    }
+    sort.Strings(names)
+    return names
+}
+
+type RandomAI struct {
+    Rng *rand.Rand
+}
+
+func (r RandomAI) Name() string { return "random" }
+
+func (r RandomAI) ChooseAction(b *Battle, side int) Action {
+    usable := b.Active(side).UsableMoves()
+    if len(usable) == 0 {
+        return MoveAction(-1)
+    }
+    return MoveAction(usable[r.Rng.Intn(len(usable))])
+}
+
+func (r RandomAI) ChooseReplacement(b *Battle, side int) int {
+    bench := b.Sides[side].Bench()
+    return bench[r.Rng.Intn(len(bench))]
+}
+
+// GreedyAI always picks the move with the highest expected damage, but
+// ignores type matchups.
+type GreedyAI struct{}
+
+func (GreedyAI) Name() string { return "greedy" }
+
+func (GreedyAI) ChooseAction(b *Battle, side int) Action {
+    me, foe := b.Active(side), b.Active(1-side)
+    return MoveAction(bestMove(me, func(m Move) float64 {
+        return b.expectedDamage(me, foe, m, false)
+    }))
+}
+
+func (GreedyAI) ChooseReplacement(b *Battle, side int) int {
+    return bestReplacement(b, side)
+}
+
+// TypeAwareAI weighs type effectiveness and switches out of matchups where
+// it cannot hit harder than it is being hit.
+type TypeAwareAI struct{}
+
+func (TypeAwareAI) Name() string { return "typeaware" }
+
+func (TypeAwareAI) ChooseAction(b *Battle, side int) Action {
+    me, foe := b.Active(side), b.Active(1-side)
+    dealt, taken := matchup(b, me, foe)
+    if dealt < taken/2 {
+        if i := bestReplacement(b, side); i >= 0 {
+            if d, t := matchup(b, b.Sides[side].Team[i], foe); d-t > dealt-taken {
+                return SwitchAction(i)
+            }
+        }
+    }
+    return MoveAction(bestMove(me, func(m Move) float64 {
+        return b.expectedDamage(me, foe, m, true)
+    }))
+}
+
+func (TypeAwareAI) ChooseReplacement(b *Battle, side int) int {
+    return bestReplacement(b, side)
+}
+
+// MinimaxAI looks one full turn ahead: for every move it assumes the
+// opponent answers with its most damaging reply and keeps the move that
+// leaves it furthest ahead on remaining HP fraction.
+type MinimaxAI struct{}
+
+func (MinimaxAI) Name() string { return "minimax" }
+
+func (MinimaxAI) ChooseAction(b *Battle, side int) Action {
+    me, foe := b.Active(side), b.Active(1-side)
+    mine, theirs := movesOrStruggle(me), movesOrStruggle(foe)
+
+    best, bestScore := mine[0], 0.0
+    for n, i := range mine {
+        worst := 0.0
+        for k, j := range theirs {
+            score := b.evaluate(side, i, j)
+            if k == 0 || score < worst {
+                worst = score
+            }
+        }
+        if n == 0 || worst > bestScore {
+            best, bestScore = i, worst
+        }
+    }
+    return MoveAction(best)
+}
+
+func (MinimaxAI) ChooseReplacement(b *Battle, side int) int {
+    return bestReplacement(b, side)
+}
+
+// evaluate estimates the HP-fraction lead for side after it uses move i and
+// the opponent uses move j, honoring turn order.
+func (b *Battle) evaluate(side, i, j int) float64 {
+    me, foe := b.Active(side), b.Active(1-side)
+    myMove, theirMove := b.moveFor(me, i), b.moveFor(foe, j)
+    myHP, foeHP := float64(me.HP), float64(foe.HP)
+
+    actions := [2]Action{}
+    actions[side], actions[1-side] = MoveAction(i), MoveAction(j)
+    meFirst := b.priority(side, actions[side]) > b.priority(1-side, actions[1-side]) ||
+        (b.priority(side, actions[side]) == b.priority(1-side, actions[1-side]) && me.Speed() >= foe.Speed())
+
+    hitFoe := func() { foeHP -= b.expectedDamage(me, foe, myMove, true) }
+    hitMe := func() { myHP -= b.expectedDamage(foe, me, theirMove, true) }
+    if meFirst {
+        hitFoe()
+        if foeHP > 0 {
+            hitMe()
+        }
+    } else {
+        hitMe()
+        if myHP > 0 {
+            hitFoe()
+        }
+    }
+    return clampFraction(myHP, me.Stats.HP) - clampFraction(foeHP, foe.Stats.HP)
+}
+
+func clampFraction(hp float64, max int) float64 {
+    if hp < 0 {
+        hp = 0
+    }
+    return hp / float64(max)
+}
+
+func movesOrStruggle(c *Combatant) []int {
+    if usable := c.UsableMoves(); len(usable) > 0 {
+        return usable
+    }
+    return []int{-1}
+}
+
+func bestMove(c *Combatant, score func(Move) float64) int {
+    usable := c.UsableMoves()
+    if len(usable) == 0 {
+        return -1
+    }
+    best, bestScore := usable[0], score(c.Moves[usable[0]])
+    for _, i := range usable[1:] {
+        if s := score(c.Moves[i]); s > bestScore {
+            best, bestScore = i, s
+        }
+    }
+    return best
+}
+
+// matchup returns the best expected damage c deals to foe and the best
+// expected damage foe deals back.
+func matchup(b *Battle, c, foe *Combatant) (dealt, taken float64) {
+    for _, i := range movesOrStruggle(c) {
+        if d := b.expectedDamage(c, foe, b.moveFor(c, i), true); d > dealt {
+            dealt = d
+        }
+    }
+    for _, i := range movesOrStruggle(foe) {
+        if d := b.expectedDamage(foe, c, b.moveFor(foe, i), true); d > taken {
+            taken = d
+        }
+    }
+    return dealt, taken
+}
+
+func bestReplacement(b *Battle, side int) int {
+    bench := b.Sides[side].Bench()
+    if len(bench) == 0 {
+        return -1
+    }
+    foe := b.Active(1 - side)
+    best, bestScore := bench[0], 0.0
+    for n, i := range bench {
+        dealt, taken := matchup(b, b.Sides[side].Team[i], foe)
+        if score := dealt - taken; n == 0 || score > bestScore {
+            best, bestScore = i, score
+        }
+    }
+    return best
+}
+
+// expectedDamage is the average damage of m without any rng draws: the mean
+// random factor, weighted by accuracy, optionally with type effectiveness.
+func (b *Battle) expectedDamage(attacker, defender *Combatant, m Move, typed bool) float64 {
+    if m.Power <= 0 {
+        return 0
+    }
+    dmg := baseDamage(attacker, defender, m) * 0.925
+    if attacker.hasType(m.Type) {
+        dmg *= 1.5
+    }
+    if typed {
+        dmg *= b.Effectiveness(m, defender)
+    }
+    if m.Accuracy > 0 {
+        dmg *= float64(m.Accuracy) / 100
+    }
+    return dmg
+}
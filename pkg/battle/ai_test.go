@@ -0,0 +1,89 @@
+package battle
+
+import (
+    "math/rand"
+    "testing"
+)
+
+func TestNewAI(t *testing.T) {
+    for _, name := range AINames() {
+        ai, err := NewAI(name, rand.New(rand.NewSource(1)))
+        if err != nil {
+            t.Fatalf("NewAI(%q) error: %v", name, err)
+        }
+        if ai.Name() != name {
+            t.Fatalf("expected AI named %q, got %q", name, ai.Name())
+        }
+    }
+    if _, err := NewAI("psychic", nil); err == nil {
+        t.Fatalf("expected error for unknown AI")
+    }
+    if len(AINames()) != 4 {
+        t.Fatalf("expected 4 built-in strategies, got %v", AINames())
+    }
+}
+
+func TestGreedyIgnoresTypesButTypeAwareDoesNot(t *testing.T) {
+    waterGun := Move{Name: "water-gun", Type: "water", Power: 40, Accuracy: 100, PP: 25, Class: Physical}
+    flamethrower := Move{Name: "flamethrower", Type: "fire", Power: 90, Accuracy: 100, PP: 15, Class: Physical}
+    me := NewCombatant("me", 50, nil, Stats{HP: 100, Attack: 50, Defense: 50}, []Move{flamethrower, waterGun})
+    foe := NewCombatant("foe", 50, []string{"fire"}, Stats{HP: 100, Attack: 50, Defense: 50}, []Move{tackle})
+    chart := chart{"water": {"fire": 2}, "fire": {"fire": 0.5}}
+    b := New(NewSide("a", me), NewSide("b", foe), rand.New(rand.NewSource(1)), chart, nil)
+
+    if got := (GreedyAI{}).ChooseAction(b, 0); got != MoveAction(0) {
+        t.Fatalf("expected greedy to pick the strongest move, got %+v", got)
+    }
+    if got := (TypeAwareAI{}).ChooseAction(b, 0); got != MoveAction(1) {
+        t.Fatalf("expected typeaware to pick the super effective move, got %+v", got)
+    }
+}
+
+func TestTypeAwareSwitchesOutOfBadMatchups(t *testing.T) {
+    ember := Move{Name: "ember", Type: "fire", Power: 40, Accuracy: 100, PP: 25, Class: Special}
+    surf := Move{Name: "surf", Type: "water", Power: 90, Accuracy: 100, PP: 15, Class: Special}
+    stats := Stats{HP: 100, Attack: 50, Defense: 50, SpAttack: 50, SpDefense: 50, Speed: 50}
+    fire := NewCombatant("fire", 50, []string{"fire"}, stats, []Move{ember})
+    water := NewCombatant("water", 50, []string{"water"}, stats, []Move{surf})
+    foe := NewCombatant("foe", 50, []string{"water"}, stats, []Move{surf})
+    chart := chart{"water": {"fire": 2, "water": 0.5}, "fire": {"water": 0.5}}
+    b := New(NewSide("a", fire, water), NewSide("b", foe), rand.New(rand.NewSource(1)), chart, nil)
+
+    if got := (TypeAwareAI{}).ChooseAction(b, 0); got != SwitchAction(1) {
+        t.Fatalf("expected typeaware to switch to the water type, got %+v", got)
+    }
+    if got := (GreedyAI{}).ChooseReplacement(b, 0); got != 1 {
+        t.Fatalf("expected best replacement to be slot 1, got %d", got)
+    }
+}
+
+func TestMinimaxPrefersPriorityKO(t *testing.T) {
+    hyperBeam := Move{Name: "hyper-beam", Type: "normal", Power: 150, Accuracy: 90, PP: 5, Class: Physical}
+    quickAttack := Move{Name: "quick-attack", Type: "normal", Power: 40, Accuracy: 100, PP: 30, Priority: 1, Class: Physical}
+    me := NewCombatant("me", 50, nil, Stats{HP: 10, Attack: 100, Defense: 100, Speed: 1}, []Move{hyperBeam, quickAttack})
+    foe := NewCombatant("foe", 50, nil, Stats{HP: 100, Attack: 200, Defense: 100, Speed: 100}, []Move{tackle})
+    foe.HP = 5
+    b := New(NewSide("a", me), NewSide("b", foe), rand.New(rand.NewSource(1)), nil, nil)
+
+    if got := (GreedyAI{}).ChooseAction(b, 0); got != MoveAction(0) {
+        t.Fatalf("expected greedy to go for hyper-beam, got %+v", got)
+    }
+    if got := (MinimaxAI{}).ChooseAction(b, 0); got != MoveAction(1) {
+        t.Fatalf("expected minimax to KO first with quick-attack, got %+v", got)
+    }
+}
+
+func TestAIsAreDeterministicForASeed(t *testing.T) {
+    for _, name := range AINames() {
+        run := func() Result {
+            rng := rand.New(rand.NewSource(7))
+            a := NewCombatant("a", 30, []string{"water"}, Stats{HP: 90, Attack: 60, Defense: 50, Speed: 40}, []Move{tackle})
+            z := NewCombatant("z", 30, []string{"fire"}, Stats{HP: 90, Attack: 60, Defense: 50, Speed: 45}, []Move{tackle})
+            ai, _ := NewAI(name, rng)
+            return New(NewSide("a", a), NewSide("z", z), rng, testChart, nil).Run([2]Controller{ai, ai})
+        }
+        if r1, r2 := run(), run(); r1 != r2 {
+            t.Fatalf("%s: expected identical results, got %+v and %+v", name, r1, r2)
+        }
+    }
+}
@@ -0,0 +1,477 @@
+package battle
+
+import (
+    "fmt"
+    "io"
+    "math/rand"
+    "strings"
+)
+
+const DefaultMaxTurns = 200
+
+const (
+    Physical = "physical"
+    Special  = "special"
+    Status   = "status"
+)
+
+type Move struct {
+    Name     string `json:"name"`
+    Type     string `json:"type"`
+    Power    int    `json:"power"`
+    Accuracy int    `json:"accuracy"`
+    PP       int    `json:"pp"`
+    Priority int    `json:"priority"`
+    Class    string `json:"class"`
+
+    Ailment       string       `json:"ailment,omitempty"`
+    AilmentChance int          `json:"ailment_chance,omitempty"`
+    StatChanges   []StatChange `json:"stat_changes,omitempty"`
+    StatChance    int          `json:"stat_chance,omitempty"`
+    StatsOnSelf   bool         `json:"stats_on_self,omitempty"`
+}
+
+func (m Move) hasEffect() bool {
+    return m.Ailment != "" || len(m.StatChanges) > 0
+}
+
+var Struggle = Move{Name: "struggle", Power: 50, Class: Physical}
+
+type Stats struct {
+    HP        int `json:"hp"`
+    Attack    int `json:"attack"`
+    Defense   int `json:"defense"`
+    SpAttack  int `json:"special_attack"`
+    SpDefense int `json:"special_defense"`
+    Speed     int `json:"speed"`
+}
+
+type Combatant struct {
+    Name  string
+    Level int
+    Types []string
+    Stats Stats
+    Moves []Move
+
+    HP     int
+    Status string
+    Stages map[string]int
+
+    // DamageDealt and KOs count what this combatant did to its opponents.
+    DamageDealt int
+    KOs         int
+
+    pp         []int
+    sleepTurns int
+    confused   int
+}
+
+func NewCombatant(name string, level int, types []string, stats Stats, moves []Move) *Combatant {
+    if level < 1 {
+        level = 1
+    }
+    if stats.HP <= 0 {
+        stats.HP = 10
+    }
+    if len(moves) > 4 {
+        moves = moves[:4]
+    }
+    c := &Combatant{Name: name, Level: level, Types: types, Stats: stats, Moves: moves, HP: stats.HP, Stages: make(map[string]int)}
+    c.pp = make([]int, len(moves))
+    for i, m := range moves {
+        c.pp[i] = m.PP
+    }
+    return c
+}
+
+func (c *Combatant) Fainted() bool {
+    return c.HP <= 0
+}
+
+func (c *Combatant) PP(i int) int {
+    return c.pp[i]
+}
+
+func (c *Combatant) CanUse(i int) bool {
+    return i >= 0 && i < len(c.Moves) && c.pp[i] > 0
+}
+
+func (c *Combatant) UsableMoves() []int {
+    var usable []int
+    for i := range c.Moves {
+        if c.CanUse(i) {
+            usable = append(usable, i)
+        }
+    }
+    return usable
+}
+
+func (c *Combatant) hasType(t string) bool {
+    for _, own := range c.Types {
+        if own == t {
+            return true
+        }
+    }
+    return false
+}
+
+type TypeChart interface {
+    Multiplier(attacking string, defending []string) float64
+}
+
+type ActionKind int
+
+const (
+    UseMove ActionKind = iota
+    SwitchTo
+    Forfeit
+)
+
+const switchPriority = 7
+
+type Action struct {
+    Kind  ActionKind `json:"kind"`
+    Index int        `json:"index"`
+}
+
+func MoveAction(i int) Action {
+    return Action{Kind: UseMove, Index: i}
+}
+
+func SwitchAction(i int) Action {
+    return Action{Kind: SwitchTo, Index: i}
+}
+
+type Controller interface {
+    ChooseAction(b *Battle, side int) Action
+    ChooseReplacement(b *Battle, side int) int
+}
+
+type Side struct {
+    Name   string
+    Team   []*Combatant
+    Active int
+}
+
+func NewSide(name string, team ...*Combatant) *Side {
+    return &Side{Name: name, Team: team}
+}
+
+func (s *Side) Current() *Combatant {
+    return s.Team[s.Active]
+}
+
+func (s *Side) Defeated() bool {
+    for _, c := range s.Team {
+        if !c.Fainted() {
+            return false
+        }
+    }
+    return true
+}
+
+func (s *Side) CanSwitchTo(i int) bool {
+    return i >= 0 && i < len(s.Team) && i != s.Active && !s.Team[i].Fainted()
+}
+
+func (s *Side) Bench() []int {
+    var bench []int
+    for i := range s.Team {
+        if s.CanSwitchTo(i) {
+            bench = append(bench, i)
+        }
+    }
+    return bench
+}
+
+type Result struct {
+    Winner int `json:"winner"`
+    Turns  int `json:"turns"`
+}
+
+const Draw = -1
+
+type Battle struct {
+    Sides    [2]*Side
+    Turn     int
+    MaxTurns int
+
+    forfeited int
+    rng       *rand.Rand
+    chart     TypeChart
+    log       io.Writer
+}
+
+func New(a, b *Side, rng *rand.Rand, chart TypeChart, log io.Writer) *Battle {
+    if log == nil {
+        log = io.Discard
+    }
+    return &Battle{Sides: [2]*Side{a, b}, MaxTurns: DefaultMaxTurns, forfeited: Draw, rng: rng, chart: chart, log: log}
+}
+
+func (b *Battle) logf(format string, args ...any) {
+    fmt.Fprintf(b.log, format+"\n", args...)
+}
+
+func (b *Battle) Active(side int) *Combatant {
+    return b.Sides[side].Current()
+}
+
+func (b *Battle) Over() bool {
+    return b.forfeited != Draw || b.Sides[0].Defeated() || b.Sides[1].Defeated()
+}
+
+func (b *Battle) Run(controllers [2]Controller) Result {
+    for !b.Over() {
+        if b.Turn >= b.MaxTurns {
+            return Result{Winner: Draw, Turns: b.Turn}
+        }
+        b.PlayTurn([2]Action{
+            controllers[0].ChooseAction(b, 0),
+            controllers[1].ChooseAction(b, 1),
+        })
+        b.ReplaceFainted(controllers)
+    }
+    return b.result()
+}
+
+func (b *Battle) result() Result {
+    if b.forfeited != Draw {
+        return Result{Winner: 1 - b.forfeited, Turns: b.Turn}
+    }
+    a, z := b.Sides[0].Defeated(), b.Sides[1].Defeated()
+    switch {
+    case z && !a:
+        return Result{Winner: 0, Turns: b.Turn}
+    case a && !z:
+        return Result{Winner: 1, Turns: b.Turn}
+    }
+    return Result{Winner: Draw, Turns: b.Turn}
+}
+
+// ReplaceFainted asks each side whose active Pokémon fainted to send out a
+// replacement from its bench.
+func (b *Battle) ReplaceFainted(controllers [2]Controller) {
+    for side, s := range b.Sides {
+        if !s.Current().Fainted() || s.Defeated() {
+            continue
+        }
+        i := controllers[side].ChooseReplacement(b, side)
+        if !s.CanSwitchTo(i) {
+            i = s.Bench()[0]
+        }
+        s.Active = i
+        b.logf("%s sent out %s!", s.Name, s.Current().Name)
+    }
+}
+
+func (b *Battle) PlayTurn(actions [2]Action) {
+    b.Turn++
+    for side, a := range actions {
+        if a.Kind == Forfeit {
+            b.forfeited = side
+            b.logf("%s forfeited the battle!", b.Sides[side].Name)
+            return
+        }
+    }
+
+    first := b.firstToAct(actions)
+    order := []int{first, 1 - first}
+    for _, side := range order {
+        if actions[side].Kind == SwitchTo {
+            b.switchIn(side, actions[side].Index)
+        }
+    }
+    for _, side := range order {
+        if actions[side].Kind != UseMove {
+            continue
+        }
+        attacker, defender := b.Active(side), b.Active(1-side)
+        if attacker.Fainted() || defender.Fainted() {
+            continue
+        }
+        if !b.canAct(attacker) {
+            continue
+        }
+        b.useMove(attacker, defender, actions[side].Index)
+    }
+    b.endOfTurn()
+}
+
+func (b *Battle) switchIn(side, i int) {
+    s := b.Sides[side]
+    if !s.CanSwitchTo(i) {
+        return
+    }
+    prev := s.Current()
+    prev.withdraw()
+    s.Active = i
+    b.logf("%s withdrew %s and sent out %s!", s.Name, prev.Name, s.Current().Name)
+}
+
+func (b *Battle) moveFor(c *Combatant, i int) Move {
+    if !c.CanUse(i) {
+        return Struggle
+    }
+    return c.Moves[i]
+}
+
+func (b *Battle) priority(side int, a Action) int {
+    if a.Kind != UseMove {
+        return switchPriority
+    }
+    return b.moveFor(b.Active(side), a.Index).Priority
+}
+
+func (b *Battle) firstToAct(actions [2]Action) int {
+    p0, p1 := b.priority(0, actions[0]), b.priority(1, actions[1])
+    if p0 != p1 {
+        if p0 > p1 {
+            return 0
+        }
+        return 1
+    }
+    s0, s1 := b.Active(0).Speed(), b.Active(1).Speed()
+    if s0 != s1 {
+        if s0 > s1 {
+            return 0
+        }
+        return 1
+    }
+    return b.rng.Intn(2)
+}
+
+func (b *Battle) useMove(attacker, defender *Combatant, i int) {
+    m := b.moveFor(attacker, i)
+    if m.Name != Struggle.Name {
+        attacker.pp[i]--
+    }
+    b.logf("%s used %s!", attacker.Name, m.Name)
+
+    if m.Power <= 0 && !m.hasEffect() {
+        b.logf("But nothing happened!")
+        return
+    }
+    if m.Accuracy > 0 && b.rng.Intn(100) >= int(float64(m.Accuracy)*accuracyMultiplier(attacker, defender)) {
+        b.logf("%s's attack missed!", attacker.Name)
+        return
+    }
+    if m.Power <= 0 {
+        b.applyEffects(attacker, defender, m)
+        return
+    }
+
+    dmg, effectiveness, crit := b.Damage(attacker, defender, m)
+    if effectiveness == 0 {
+        b.logf("It doesn't affect %s...", defender.Name)
+        return
+    }
+    if crit {
+        b.logf("A critical hit!")
+    }
+    switch {
+    case effectiveness > 1:
+        b.logf("It's super effective!")
+    case effectiveness < 1:
+        b.logf("It's not very effective...")
+    }
+    if dmg > defender.HP {
+        attacker.DamageDealt += defender.HP
+    } else {
+        attacker.DamageDealt += dmg
+    }
+    defender.HP -= dmg
+    if defender.HP < 0 {
+        defender.HP = 0
+    }
+    b.logf("%s took %d damage (%d HP left)", defender.Name, dmg, defender.HP)
+    if defender.Fainted() {
+        attacker.KOs++
+    }
+
+    if m.Name == Struggle.Name {
+        recoil := dmg / 4
+        if recoil < 1 {
+            recoil = 1
+        }
+        attacker.HP -= recoil
+        if attacker.HP < 0 {
+            attacker.HP = 0
+        }
+        b.logf("%s is hit with recoil (%d HP left)", attacker.Name, attacker.HP)
+    }
+    if !defender.Fainted() && !attacker.Fainted() {
+        b.applyEffects(attacker, defender, m)
+    }
+    if defender.Fainted() {
+        b.logf("%s fainted!", defender.Name)
+    }
+    if attacker.Fainted() {
+        b.logf("%s fainted!", attacker.Name)
+    }
+}
+
+func (b *Battle) Effectiveness(m Move, defender *Combatant) float64 {
+    if b.chart == nil || m.Type == "" {
+        return 1
+    }
+    return b.chart.Multiplier(m.Type, defender.Types)
+}
+
+// Damage rolls a critical hit and the random factor and applies the
+// standard damage formula with STAB and type effectiveness.
+func (b *Battle) Damage(attacker, defender *Combatant, m Move) (dmg int, effectiveness float64, crit bool) {
+    crit = b.rng.Intn(24) == 0
+    factor := 0.85 + b.rng.Float64()*0.15
+    effectiveness = b.Effectiveness(m, defender)
+    if effectiveness == 0 {
+        return 0, 0, crit
+    }
+    modifier := factor * effectiveness
+    if crit {
+        modifier *= 1.5
+    }
+    if attacker.hasType(m.Type) {
+        modifier *= 1.5
+    }
+    dmg = int(baseDamage(attacker, defender, m) * modifier)
+    if dmg < 1 {
+        dmg = 1
+    }
+    return dmg, effectiveness, crit
+}
+
+// baseDamage applies stat stages to the attacking and defending stats and
+// halves physical attacks from a burned attacker.
+func baseDamage(attacker, defender *Combatant, m Move) float64 {
+    atkStat, defStat := "attack", "defense"
+    atk, def := attacker.Stats.Attack, defender.Stats.Defense
+    if m.Class == Special {
+        atkStat, defStat = "special-attack", "special-defense"
+        atk, def = attacker.Stats.SpAttack, defender.Stats.SpDefense
+    }
+    if atk <= 0 {
+        atk = 5
+    }
+    if def <= 0 {
+        def = 1
+    }
+    a := float64(atk) * stageMultiplier(attacker.Stages[atkStat])
+    d := float64(def) * stageMultiplier(defender.Stages[defStat])
+    if attacker.Status == Burn && m.Class != Special {
+        a /= 2
+    }
+    level := float64(attacker.Level)
+    return (2*level/5+2)*float64(m.Power)*a/d/50 + 2
+}
+
+func HPBar(c *Combatant, width int) string {
+    filled := 0
+    if c.HP > 0 {
+        filled = (c.HP*width + c.Stats.HP - 1) / c.Stats.HP
+    }
+    if filled > width {
+        filled = width
+    }
+    return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
@@ -0,0 +1,194 @@
+package battle
+
+import (
+    "bytes"
+    "math"
+    "math/rand"
+    "strings"
+    "testing"
+)
+
+type chart map[string]map[string]float64
+
+func (c chart) Multiplier(attacking string, defending []string) float64 {
+    mult := 1.0
+    for _, d := range defending {
+        if m, ok := c[attacking][d]; ok {
+            mult *= m
+        }
+    }
+    return mult
+}
+
+var testChart = chart{
+    "water":  {"fire": 2},
+    "fire":   {"water": 0.5},
+    "normal": {"ghost": 0},
+}
+
+var tackle = Move{Name: "tackle", Type: "normal", Power: 40, Accuracy: 100, PP: 35, Class: Physical}
+
+func fixed(move int) Controller {
+    return fixedController(move)
+}
+
+type fixedController int
+
+func (f fixedController) ChooseAction(b *Battle, side int) Action {
+    return MoveAction(int(f))
+}
+
+func (f fixedController) ChooseReplacement(b *Battle, side int) int {
+    return b.Sides[side].Bench()[0]
+}
+
+func TestBaseDamageFormula(t *testing.T) {
+    a := NewCombatant("a", 50, nil, Stats{HP: 100, Attack: 100, SpAttack: 50}, nil)
+    d := NewCombatant("d", 50, nil, Stats{HP: 100, Defense: 100, SpDefense: 25}, nil)
+
+    if got := baseDamage(a, d, tackle); math.Abs(got-19.6) > 1e-9 {
+        t.Fatalf("expected physical base damage 19.6, got %v", got)
+    }
+    special := Move{Name: "ember", Power: 40, Class: Special}
+    if got := baseDamage(a, d, special); math.Abs(got-37.2) > 1e-9 {
+        t.Fatalf("expected special base damage 37.2, got %v", got)
+    }
+}
+
+func TestTurnOrderUsesPriorityThenSpeed(t *testing.T) {
+    quick := Move{Name: "quick-attack", Type: "normal", Power: 40, Accuracy: 100, PP: 30, Priority: 1, Class: Physical}
+    slow := NewCombatant("slow", 5, nil, Stats{HP: 50, Attack: 10, Defense: 10, Speed: 5}, []Move{tackle, quick})
+    fast := NewCombatant("fast", 5, nil, Stats{HP: 50, Attack: 10, Defense: 10, Speed: 50}, []Move{tackle})
+    b := New(NewSide("slow", slow), NewSide("fast", fast), rand.New(rand.NewSource(1)), nil, nil)
+
+    if got := b.firstToAct([2]Action{MoveAction(0), MoveAction(0)}); got != 1 {
+        t.Fatalf("expected faster combatant to act first, got side %d", got)
+    }
+    if got := b.firstToAct([2]Action{MoveAction(1), MoveAction(0)}); got != 0 {
+        t.Fatalf("expected priority move to act first, got side %d", got)
+    }
+}
+
+func TestTypeEffectivenessAndImmunity(t *testing.T) {
+    waterGun := Move{Name: "water-gun", Type: "water", Power: 40, Accuracy: 100, PP: 25, Class: Special}
+    squirtle := NewCombatant("squirtle", 10, []string{"water"}, Stats{HP: 100, SpAttack: 50, Attack: 50, Defense: 50, SpDefense: 50}, []Move{waterGun, tackle})
+    charmander := NewCombatant("charmander", 10, []string{"fire"}, Stats{HP: 100, SpAttack: 50, Attack: 50, Defense: 50, SpDefense: 50}, nil)
+    gastly := NewCombatant("gastly", 10, []string{"ghost"}, Stats{HP: 100, Defense: 50}, nil)
+
+    log := &bytes.Buffer{}
+    b := New(NewSide("squirtle", squirtle), NewSide("charmander", charmander), rand.New(rand.NewSource(1)), testChart, log)
+    b.useMove(squirtle, charmander, 0)
+    if !strings.Contains(log.String(), "It's super effective!") {
+        t.Fatalf("expected super effective message:\n%s", log.String())
+    }
+
+    log.Reset()
+    b.useMove(squirtle, gastly, 1)
+    if !strings.Contains(log.String(), "It doesn't affect gastly...") || gastly.HP != 100 {
+        t.Fatalf("expected ghost to be immune to normal moves:\n%s", log.String())
+    }
+    if squirtle.PP(1) != tackle.PP-1 {
+        t.Fatalf("expected PP to be spent, got %d", squirtle.PP(1))
+    }
+}
+
+func TestStruggleWhenOutOfPP(t *testing.T) {
+    splash := Move{Name: "pound", Type: "normal", Power: 40, Accuracy: 100, PP: 1, Class: Physical}
+    a := NewCombatant("a", 5, nil, Stats{HP: 500, Attack: 10, Defense: 10, Speed: 10}, []Move{splash})
+    z := NewCombatant("z", 5, nil, Stats{HP: 500, Attack: 10, Defense: 10, Speed: 1}, []Move{splash})
+    log := &bytes.Buffer{}
+    b := New(NewSide("a", a), NewSide("z", z), rand.New(rand.NewSource(1)), nil, log)
+
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    if !strings.Contains(log.String(), "a used struggle!") || !strings.Contains(log.String(), "is hit with recoil") {
+        t.Fatalf("expected struggle once PP ran out:\n%s", log.String())
+    }
+    if got := (RandomAI{Rng: rand.New(rand.NewSource(1))}).ChooseAction(b, 0); got != MoveAction(-1) {
+        t.Fatalf("expected random AI to fall back to struggle, got %+v", got)
+    }
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+    run := func() (Result, string) {
+        a := NewCombatant("a", 20, []string{"water"}, Stats{HP: 60, Attack: 50, Defense: 40, Speed: 30}, []Move{tackle})
+        z := NewCombatant("z", 20, []string{"fire"}, Stats{HP: 60, Attack: 55, Defense: 35, Speed: 40}, []Move{tackle})
+        log := &bytes.Buffer{}
+        rng := rand.New(rand.NewSource(42))
+        res := New(NewSide("a", a), NewSide("z", z), rng, testChart, log).Run([2]Controller{RandomAI{Rng: rng}, fixed(0)})
+        return res, log.String()
+    }
+    r1, log1 := run()
+    r2, log2 := run()
+    if r1 != r2 || log1 != log2 {
+        t.Fatalf("expected identical battles for the same seed")
+    }
+    if r1.Winner == Draw || r1.Turns == 0 {
+        t.Fatalf("expected a decisive battle, got %+v", r1)
+    }
+}
+
+func TestSwitchingAndForcedReplacement(t *testing.T) {
+    lead := NewCombatant("lead", 5, nil, Stats{HP: 1, Attack: 10, Defense: 10, Speed: 1}, []Move{tackle})
+    backup := NewCombatant("backup", 5, nil, Stats{HP: 500, Attack: 10, Defense: 10, Speed: 1}, []Move{tackle})
+    foe := NewCombatant("foe", 5, nil, Stats{HP: 500, Attack: 10, Defense: 10, Speed: 50}, []Move{tackle})
+    log := &bytes.Buffer{}
+    b := New(NewSide("red", lead, backup), NewSide("blue", foe), rand.New(rand.NewSource(1)), nil, log)
+
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    if !lead.Fainted() || b.Over() {
+        t.Fatalf("expected lead to faint without ending the battle:\n%s", log.String())
+    }
+    b.ReplaceFainted([2]Controller{fixed(0), fixed(0)})
+    if b.Active(0) != backup || !strings.Contains(log.String(), "red sent out backup!") {
+        t.Fatalf("expected backup to be sent out:\n%s", log.String())
+    }
+
+    b.PlayTurn([2]Action{SwitchAction(0), MoveAction(0)})
+    if b.Active(0) != backup {
+        t.Fatalf("expected switch to a fainted pokemon to be ignored")
+    }
+
+    b.PlayTurn([2]Action{{Kind: Forfeit}, MoveAction(0)})
+    if !b.Over() || b.result().Winner != 1 {
+        t.Fatalf("expected forfeit to hand the win to blue, got %+v", b.result())
+    }
+}
+
+func TestSwitchHappensBeforeMoves(t *testing.T) {
+    a1 := NewCombatant("a1", 5, nil, Stats{HP: 100, Attack: 10, Defense: 10, Speed: 1}, []Move{tackle})
+    a2 := NewCombatant("a2", 5, nil, Stats{HP: 100, Attack: 10, Defense: 10, Speed: 1}, []Move{tackle})
+    foe := NewCombatant("foe", 5, nil, Stats{HP: 100, Attack: 10, Defense: 10, Speed: 99}, []Move{tackle})
+    b := New(NewSide("red", a1, a2), NewSide("blue", foe), rand.New(rand.NewSource(1)), nil, nil)
+
+    b.PlayTurn([2]Action{SwitchAction(1), MoveAction(0)})
+    if a1.HP != 100 || a2.HP == 100 {
+        t.Fatalf("expected the incoming pokemon to take the hit, got a1=%d a2=%d", a1.HP, a2.HP)
+    }
+}
+
+func TestHPBar(t *testing.T) {
+    c := NewCombatant("c", 5, nil, Stats{HP: 40}, nil)
+    c.HP = 10
+    if got := HPBar(c, 8); got != "[##------]" {
+        t.Fatalf("unexpected bar %q", got)
+    }
+    c.HP = 1
+    if got := HPBar(c, 8); got != "[#-------]" {
+        t.Fatalf("expected a sliver for low HP, got %q", got)
+    }
+}
+
+func TestDamageDealtAndKOsAreTracked(t *testing.T) {
+    strong := NewCombatant("strong", 50, nil, Stats{HP: 100, Attack: 500, Defense: 50, Speed: 50}, []Move{tackle})
+    weak := NewCombatant("weak", 5, nil, Stats{HP: 12, Attack: 5, Defense: 5, Speed: 5}, []Move{tackle})
+    b := New(NewSide("a", strong), NewSide("b", weak), rand.New(rand.NewSource(1)), nil, nil)
+    b.Run([2]Controller{fixed(0), fixed(0)})
+
+    if strong.KOs != 1 || strong.DamageDealt != 12 {
+        t.Fatalf("expected 1 KO and damage capped at remaining HP, got %d KOs %d damage", strong.KOs, strong.DamageDealt)
+    }
+    if weak.KOs != 0 || weak.DamageDealt != 0 {
+        t.Fatalf("expected weak to have dealt nothing, got %d KOs %d damage", weak.KOs, weak.DamageDealt)
+    }
+}
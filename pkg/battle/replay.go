@@ -0,0 +1,201 @@
+package battle
+
+import (
+    "bytes"
+    "fmt"
+    "math/rand"
+    "strings"
+)
+
+// EngineVersion changes whenever battle mechanics change in a way that
+// alters the log of a replayed battle.
+const EngineVersion = 1
+
+// Replay holds everything needed to re-run a battle: the seed of the
+// engine's rng, the starting teams, every decision each side made, the
+// type matchups that were in play and the log the battle produced.
+type Replay struct {
+    EngineVersion int                           `json:"engine_version"`
+    Seed          int64                         `json:"seed"`
+    MaxTurns      int                           `json:"max_turns"`
+    Sides         [2]ReplaySide                 `json:"sides"`
+    Chart         map[string]map[string]float64 `json:"chart,omitempty"`
+    Result        Result                        `json:"result"`
+    Log           string                        `json:"log"`
+}
+
+type ReplaySide struct {
+    Name         string          `json:"name"`
+    Controller   string          `json:"controller"`
+    Team         []CombatantSpec `json:"team"`
+    Actions      []Action        `json:"actions"`
+    Replacements []int           `json:"replacements"`
+}
+
+// CombatantSpec is the starting state of a combatant.
+type CombatantSpec struct {
+    Name  string   `json:"name"`
+    Level int      `json:"level"`
+    Types []string `json:"types"`
+    Stats Stats    `json:"stats"`
+    Moves []Move   `json:"moves"`
+}
+
+func Spec(c *Combatant) CombatantSpec {
+    return CombatantSpec{Name: c.Name, Level: c.Level, Types: c.Types, Stats: c.Stats, Moves: c.Moves}
+}
+
+func (s CombatantSpec) Combatant() *Combatant {
+    return NewCombatant(s.Name, s.Level, s.Types, s.Stats, s.Moves)
+}
+
+// Recorder wraps a Controller and remembers every decision it makes.
+type Recorder struct {
+    Controller
+    Actions      []Action
+    Replacements []int
+}
+
+func (r *Recorder) ChooseAction(b *Battle, side int) Action {
+    a := r.Controller.ChooseAction(b, side)
+    r.Actions = append(r.Actions, a)
+    return a
+}
+
+func (r *Recorder) ChooseReplacement(b *Battle, side int) int {
+    i := r.Controller.ChooseReplacement(b, side)
+    r.Replacements = append(r.Replacements, i)
+    return i
+}
+
+// NewReplay snapshots the sides of a battle that has not started yet.
+// Decisions, result and log are filled in by Finish.
+func NewReplay(seed int64, b *Battle, controllers [2]string) *Replay {
+    r := &Replay{EngineVersion: EngineVersion, Seed: seed, MaxTurns: b.MaxTurns}
+    for i, s := range b.Sides {
+        r.Sides[i] = ReplaySide{Name: s.Name, Controller: controllers[i]}
+        for _, c := range s.Team {
+            r.Sides[i].Team = append(r.Sides[i].Team, Spec(c))
+        }
+    }
+    if b.chart != nil {
+        r.Chart = snapshotChart(b)
+    }
+    return r
+}
+
+func (r *Replay) Finish(recorders [2]*Recorder, res Result, log string) {
+    for i, rec := range recorders {
+        r.Sides[i].Actions = rec.Actions
+        r.Sides[i].Replacements = rec.Replacements
+    }
+    r.Result = res
+    r.Log = log
+}
+
+// snapshotChart records the multiplier of every move type either side
+// carries against every defending type combination on the other side.
+func snapshotChart(b *Battle) map[string]map[string]float64 {
+    chart := make(map[string]map[string]float64)
+    for i, s := range b.Sides {
+        for _, attacker := range s.Team {
+            for _, m := range append([]Move{Struggle}, attacker.Moves...) {
+                if m.Type == "" {
+                    continue
+                }
+                if chart[m.Type] == nil {
+                    chart[m.Type] = make(map[string]float64)
+                }
+                for _, defender := range b.Sides[1-i].Team {
+                    chart[m.Type][typeKey(defender.Types)] = b.chart.Multiplier(m.Type, defender.Types)
+                }
+            }
+        }
+    }
+    return chart
+}
+
+func typeKey(types []string) string {
+    return strings.Join(types, "/")
+}
+
+type replayChart map[string]map[string]float64
+
+func (c replayChart) Multiplier(attacking string, defending []string) float64 {
+    if m, ok := c[attacking][typeKey(defending)]; ok {
+        return m
+    }
+    return 1
+}
+
+// Play re-runs the replay from its seed and recorded decisions and returns
+// the log it produces.
+func (r *Replay) Play() (string, Result) {
+    var sides [2]*Side
+    var controllers [2]Controller
+    for i, rs := range r.Sides {
+        sides[i] = NewSide(rs.Name)
+        for _, spec := range rs.Team {
+            sides[i].Team = append(sides[i].Team, spec.Combatant())
+        }
+        controllers[i] = &scripted{actions: rs.Actions, replacements: rs.Replacements}
+    }
+    var chart TypeChart
+    if r.Chart != nil {
+        chart = replayChart(r.Chart)
+    }
+    log := &bytes.Buffer{}
+    b := New(sides[0], sides[1], rand.New(rand.NewSource(r.Seed)), chart, log)
+    if r.MaxTurns > 0 {
+        b.MaxTurns = r.MaxTurns
+    }
+    res := b.Run(controllers)
+    return log.String(), res
+}
+
+// Divergence compares a replayed log against the recorded one and
+// describes the first line that differs, or returns "" if they match.
+func (r *Replay) Divergence(replayed string) string {
+    if replayed == r.Log {
+        return ""
+    }
+    want, got := strings.Split(r.Log, "\n"), strings.Split(replayed, "\n")
+    for i := 0; i < len(want) || i < len(got); i++ {
+        var w, g string
+        if i < len(want) {
+            w = want[i]
+        }
+        if i < len(got) {
+            g = got[i]
+        }
+        if w != g {
+            return fmt.Sprintf("line %d: recorded %q, replayed %q", i+1, w, g)
+        }
+    }
+    return "logs differ"
+}
+
+// scripted feeds back recorded decisions. Running out of actions means the
+// replay has diverged, so the side forfeits rather than guessing.
+type scripted struct {
+    actions      []Action
+    replacements []int
+}
+
+func (s *scripted) ChooseAction(b *Battle, side int) Action {
+    if len(s.actions) == 0 {
+        return Action{Kind: Forfeit}
+    }
+    a := s.actions[0]
+    s.actions = s.actions[1:]
+    return a
+}
+
+func (s *scripted) ChooseReplacement(b *Battle, side int) int {
+    if len(s.replacements) == 0 {
+        return -1
+    }
+    i := s.replacements[0]
+    s.replacements = s.replacements[1:]
+    return i
+}
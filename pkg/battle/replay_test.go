@@ -0,0 +1,64 @@
+package battle
+
+import (
+    "bytes"
+    "encoding/json"
+    "math/rand"
+    "strings"
+    "testing"
+)
+
+func recordBattle(t *testing.T, seed int64) *Replay {
+    t.Helper()
+    ember := Move{Name: "ember", Type: "fire", Power: 40, Accuracy: 100, PP: 25, Class: Special, Ailment: Burn, AilmentChance: 10}
+    waterGun := Move{Name: "water-gun", Type: "water", Power: 40, Accuracy: 100, PP: 25, Class: Special}
+    stats := Stats{HP: 60, Attack: 40, Defense: 40, SpAttack: 50, SpDefense: 45, Speed: 45}
+    a := NewSide("a",
+        NewCombatant("charmander", 12, []string{"fire"}, stats, []Move{ember, tackle}),
+        NewCombatant("rattata", 12, []string{"normal"}, stats, []Move{tackle}))
+    z := NewSide("z",
+        NewCombatant("squirtle", 12, []string{"water"}, stats, []Move{waterGun, tackle, growl}),
+        NewCombatant("pidgey", 12, []string{"normal", "flying"}, stats, []Move{tackle}))
+
+    log := &bytes.Buffer{}
+    b := New(a, z, rand.New(rand.NewSource(seed)), testChart, log)
+    rep := NewReplay(seed, b, [2]string{"random", "typeaware"})
+    ai := rand.New(rand.NewSource(99))
+    recorders := [2]*Recorder{{Controller: RandomAI{Rng: ai}}, {Controller: TypeAwareAI{}}}
+    res := b.Run([2]Controller{recorders[0], recorders[1]})
+    rep.Finish(recorders, res, log.String())
+    return rep
+}
+
+func TestReplayReproducesLog(t *testing.T) {
+    rep := recordBattle(t, 42)
+    data, err := json.Marshal(rep)
+    if err != nil {
+        t.Fatalf("marshal: %v", err)
+    }
+    var loaded Replay
+    if err := json.Unmarshal(data, &loaded); err != nil {
+        t.Fatalf("unmarshal: %v", err)
+    }
+
+    log, res := loaded.Play()
+    if diff := loaded.Divergence(log); diff != "" {
+        t.Fatalf("expected identical replay, diverged at %s", diff)
+    }
+    if res != rep.Result {
+        t.Fatalf("expected result %+v, got %+v", rep.Result, res)
+    }
+    if loaded.Chart["water"]["fire"] != 2 {
+        t.Fatalf("expected chart snapshot to include water vs fire, got %v", loaded.Chart)
+    }
+}
+
+func TestReplayFlagsDivergence(t *testing.T) {
+    rep := recordBattle(t, 7)
+    rep.Seed++
+    log, _ := rep.Play()
+    diff := rep.Divergence(log)
+    if diff == "" || !strings.HasPrefix(diff, "line ") {
+        t.Fatalf("expected a divergence after changing the seed, got %q", diff)
+    }
+}
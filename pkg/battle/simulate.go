@@ -0,0 +1,105 @@
+package battle
+
+import (
+    "context"
+    "math"
+    "math/rand"
+    "sync"
+)
+
+// Setup builds fresh sides and controllers for one simulated battle. rng is
+// that battle's own generator, safe to hand to AIs.
+type Setup func(rng *rand.Rand) ([2]*Side, [2]Controller)
+
+type SimResult struct {
+    Runs  int
+    Wins  [2]int
+    Draws int
+    Turns int
+}
+
+func (r SimResult) WinRate(side int) float64 {
+    if r.Runs == 0 {
+        return 0
+    }
+    return float64(r.Wins[side]) / float64(r.Runs)
+}
+
+func (r SimResult) DrawRate() float64 {
+    if r.Runs == 0 {
+        return 0
+    }
+    return float64(r.Draws) / float64(r.Runs)
+}
+
+func (r SimResult) MeanTurns() float64 {
+    if r.Runs == 0 {
+        return 0
+    }
+    return float64(r.Turns) / float64(r.Runs)
+}
+
+// Interval95 is the Wilson score interval for side's win rate at 95%
+// confidence.
+func (r SimResult) Interval95(side int) (lo, hi float64) {
+    if r.Runs == 0 {
+        return 0, 0
+    }
+    const z = 1.959964
+    n := float64(r.Runs)
+    p := r.WinRate(side)
+    center := (p + z*z/(2*n)) / (1 + z*z/n)
+    margin := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+    return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// Simulate runs one silent battle per seed across workers goroutines. The
+// result only depends on the seeds, not on how runs are scheduled.
+func Simulate(ctx context.Context, seeds []int64, workers int, chart TypeChart, setup Setup) (SimResult, error) {
+    if workers < 1 {
+        workers = 1
+    }
+    jobs := make(chan int64)
+    results := make(chan Result)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for seed := range jobs {
+                rng := rand.New(rand.NewSource(seed))
+                sides, controllers := setup(rng)
+                results <- New(sides[0], sides[1], rng, chart, nil).Run(controllers)
+            }
+        }()
+    }
+    go func() {
+        defer close(jobs)
+        for _, seed := range seeds {
+            select {
+            case jobs <- seed:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    go func() {
+        wg.Wait()
+        close(results)
+    }()
+
+    var sum SimResult
+    for res := range results {
+        sum.Runs++
+        sum.Turns += res.Turns
+        if res.Winner == Draw {
+            sum.Draws++
+        } else {
+            sum.Wins[res.Winner]++
+        }
+    }
+    if err := ctx.Err(); err != nil {
+        return sum, err
+    }
+    return sum, nil
+}
@@ -0,0 +1,61 @@
+package battle
+
+import (
+    "context"
+    "math/rand"
+    "testing"
+)
+
+func simSetup(rng *rand.Rand) ([2]*Side, [2]Controller) {
+    stats := Stats{HP: 60, Attack: 50, Defense: 50, Speed: 50}
+    a := NewCombatant("a", 20, nil, stats, []Move{tackle})
+    stronger := stats
+    stronger.Attack = 60
+    z := NewCombatant("z", 20, nil, stronger, []Move{tackle})
+    ai := RandomAI{Rng: rng}
+    return [2]*Side{NewSide("a", a), NewSide("z", z)}, [2]Controller{ai, ai}
+}
+
+func TestSimulateIsDeterministicAcrossWorkers(t *testing.T) {
+    seeds := make([]int64, 500)
+    for i := range seeds {
+        seeds[i] = int64(i)
+    }
+    one, err := Simulate(context.Background(), seeds, 1, nil, simSetup)
+    if err != nil {
+        t.Fatalf("Simulate error: %v", err)
+    }
+    many, err := Simulate(context.Background(), seeds, 8, nil, simSetup)
+    if err != nil {
+        t.Fatalf("Simulate error: %v", err)
+    }
+    if one != many {
+        t.Fatalf("expected identical results regardless of workers: %+v vs %+v", one, many)
+    }
+    if one.Runs != 500 || one.Wins[0]+one.Wins[1]+one.Draws != 500 {
+        t.Fatalf("unexpected totals %+v", one)
+    }
+    if one.WinRate(1) <= one.WinRate(0) {
+        t.Fatalf("expected the stronger side to win more often: %+v", one)
+    }
+    lo, hi := one.Interval95(1)
+    if !(lo < one.WinRate(1) && one.WinRate(1) < hi) || hi-lo > 0.1 {
+        t.Fatalf("unexpected interval [%v, %v] around %v", lo, hi, one.WinRate(1))
+    }
+}
+
+func TestSimulateStopsWhenCancelled(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    res, err := Simulate(ctx, make([]int64, 10000), 4, nil, simSetup)
+    if err == nil || res.Runs == 10000 {
+        t.Fatalf("expected cancellation to stop the simulation early, got %+v, %v", res, err)
+    }
+}
+
+func TestInterval95(t *testing.T) {
+    lo, hi := SimResult{Runs: 100, Wins: [2]int{50, 50}}.Interval95(0)
+    if lo < 0.40 || lo > 0.41 || hi < 0.59 || hi > 0.60 {
+        t.Fatalf("unexpected interval [%v, %v]", lo, hi)
+    }
+}
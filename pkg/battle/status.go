@@ -0,0 +1,264 @@
+package battle
+
+const (
+    Poison    = "poison"
+    Burn      = "burn"
+    Paralysis = "paralysis"
+    Sleep     = "sleep"
+    Freeze    = "freeze"
+    Confusion = "confusion"
+)
+
+const maxStage = 6
+
+type StatChange struct {
+    Stat   string `json:"stat"`
+    Change int    `json:"change"`
+}
+
+// statusImmunities lists the types that cannot receive each major status.
+var statusImmunities = map[string][]string{
+    Poison:    {"poison", "steel"},
+    Burn:      {"fire"},
+    Paralysis: {"electric"},
+    Freeze:    {"ice"},
+}
+
+var statusApplied = map[string]string{
+    Poison:    "%s was poisoned!",
+    Burn:      "%s was burned!",
+    Paralysis: "%s is paralyzed! It may be unable to move!",
+    Sleep:     "%s fell asleep!",
+    Freeze:    "%s was frozen solid!",
+}
+
+func (c *Combatant) Confused() bool {
+    return c.confused > 0
+}
+
+// Speed is the Speed stat after stages and paralysis.
+func (c *Combatant) Speed() int {
+    speed := float64(c.Stats.Speed) * stageMultiplier(c.Stages["speed"])
+    if c.Status == Paralysis {
+        speed /= 2
+    }
+    return int(speed)
+}
+
+// withdraw clears the effects that do not survive switching out.
+func (c *Combatant) withdraw() {
+    c.Stages = make(map[string]int)
+    c.confused = 0
+}
+
+func stageMultiplier(stage int) float64 {
+    if stage >= 0 {
+        return float64(2+stage) / 2
+    }
+    return 2 / float64(2-stage)
+}
+
+func accuracyMultiplier(attacker, defender *Combatant) float64 {
+    stage := attacker.Stages["accuracy"] - defender.Stages["evasion"]
+    if stage > maxStage {
+        stage = maxStage
+    }
+    if stage < -maxStage {
+        stage = -maxStage
+    }
+    if stage >= 0 {
+        return float64(3+stage) / 3
+    }
+    return 3 / float64(3-stage)
+}
+
+// canAct resolves sleep, freeze, confusion and paralysis before c moves and
+// reports whether it still gets to use its move.
+func (b *Battle) canAct(c *Combatant) bool {
+    switch c.Status {
+    case Sleep:
+        if c.sleepTurns > 0 {
+            c.sleepTurns--
+            b.logf("%s is fast asleep.", c.Name)
+            return false
+        }
+        c.Status = ""
+        b.logf("%s woke up!", c.Name)
+    case Freeze:
+        if b.rng.Intn(5) != 0 {
+            b.logf("%s is frozen solid!", c.Name)
+            return false
+        }
+        c.Status = ""
+        b.logf("%s thawed out!", c.Name)
+    }
+
+    if c.confused > 0 {
+        c.confused--
+        if c.confused == 0 {
+            b.logf("%s snapped out of its confusion!", c.Name)
+        } else {
+            b.logf("%s is confused!", c.Name)
+            if b.rng.Intn(3) == 0 {
+                dmg := int(baseDamage(c, c, Move{Power: 40, Class: Physical}))
+                c.HP -= dmg
+                if c.HP < 0 {
+                    c.HP = 0
+                }
+                b.logf("It hurt itself in its confusion! (%d HP left)", c.HP)
+                if c.Fainted() {
+                    b.logf("%s fainted!", c.Name)
+                }
+                return false
+            }
+        }
+    }
+
+    if c.Status == Paralysis && b.rng.Intn(4) == 0 {
+        b.logf("%s is fully paralyzed!", c.Name)
+        return false
+    }
+    return true
+}
+
+// endOfTurn deals poison and burn damage to the active Pokémon.
+func (b *Battle) endOfTurn() {
+    for side := range b.Sides {
+        c := b.Active(side)
+        if c.Fainted() {
+            continue
+        }
+        var dmg int
+        switch c.Status {
+        case Poison:
+            dmg = c.Stats.HP / 8
+            b.logf("%s is hurt by poison!", c.Name)
+        case Burn:
+            dmg = c.Stats.HP / 16
+            b.logf("%s is hurt by its burn!", c.Name)
+        default:
+            continue
+        }
+        if dmg < 1 {
+            dmg = 1
+        }
+        c.HP -= dmg
+        if c.HP < 0 {
+            c.HP = 0
+        }
+        b.logf("%s took %d damage (%d HP left)", c.Name, dmg, c.HP)
+        if c.Fainted() {
+            b.logf("%s fainted!", c.Name)
+        }
+    }
+}
+
+// applyEffects inflicts m's ailment and stat changes. A zero chance means
+// the effect always happens, as PokeAPI reports for status moves.
+func (b *Battle) applyEffects(attacker, defender *Combatant, m Move) {
+    primary := m.Power <= 0
+    if m.Ailment != "" && b.roll(m.AilmentChance) {
+        b.inflict(defender, m.Ailment, primary)
+    }
+    if len(m.StatChanges) > 0 && b.roll(m.StatChance) {
+        target := defender
+        if m.StatsOnSelf {
+            target = attacker
+        }
+        for _, sc := range m.StatChanges {
+            b.changeStage(target, sc)
+        }
+    }
+}
+
+func (b *Battle) roll(chance int) bool {
+    return chance <= 0 || chance >= 100 || b.rng.Intn(100) < chance
+}
+
+// inflict applies an ailment; failures are only logged for moves whose
+// whole purpose is the ailment.
+func (b *Battle) inflict(c *Combatant, ailment string, primary bool) {
+    fail := func(format string) {
+        if primary {
+            b.logf(format, c.Name)
+        }
+    }
+    if ailment == Confusion {
+        if c.confused > 0 {
+            fail("%s is already confused!")
+            return
+        }
+        c.confused = 2 + b.rng.Intn(4)
+        b.logf("%s became confused!", c.Name)
+        return
+    }
+    msg, ok := statusApplied[ailment]
+    if !ok {
+        return
+    }
+    if c.Status != "" {
+        fail("But it failed!")
+        return
+    }
+    for _, t := range statusImmunities[ailment] {
+        if c.hasType(t) {
+            fail("It doesn't affect %s...")
+            return
+        }
+    }
+    c.Status = ailment
+    if ailment == Sleep {
+        c.sleepTurns = 1 + b.rng.Intn(3)
+    }
+    b.logf(msg, c.Name)
+}
+
+func (b *Battle) changeStage(c *Combatant, sc StatChange) {
+    cur := c.Stages[sc.Stat]
+    next := cur + sc.Change
+    if next > maxStage {
+        next = maxStage
+    }
+    if next < -maxStage {
+        next = -maxStage
+    }
+    if next == cur {
+        if sc.Change > 0 {
+            b.logf("%s's %s won't go any higher!", c.Name, sc.Stat)
+        } else {
+            b.logf("%s's %s won't go any lower!", c.Name, sc.Stat)
+        }
+        return
+    }
+    c.Stages[sc.Stat] = next
+    b.logf("%s's %s %s", c.Name, sc.Stat, stageVerb(sc.Change))
+}
+
+func stageVerb(change int) string {
+    switch {
+    case change >= 3:
+        return "rose drastically!"
+    case change == 2:
+        return "rose sharply!"
+    case change > 0:
+        return "rose!"
+    case change <= -3:
+        return "severely fell!"
+    case change == -2:
+        return "harshly fell!"
+    }
+    return "fell!"
+}
+
+// StatusLabel is a short tag such as "PSN" for c's status, or "" when c is
+// healthy.
+func StatusLabel(c *Combatant) string {
+    label := map[string]string{Poison: "PSN", Burn: "BRN", Paralysis: "PAR", Sleep: "SLP", Freeze: "FRZ"}[c.Status]
+    if c.Confused() {
+        if label != "" {
+            label += "+"
+        }
+        label += "CNF"
+    }
+    return label
+}
@@ -0,0 +1,172 @@
+package battle
+
+import (
+    "bytes"
+    "math/rand"
+    "strings"
+    "testing"
+)
+
+var (
+    thunderWave = Move{Name: "thunder-wave", Type: "electric", Accuracy: 90, PP: 20, Class: Status, Ailment: Paralysis}
+    toxic       = Move{Name: "poison-powder", Type: "poison", Accuracy: 100, PP: 35, Class: Status, Ailment: Poison}
+    growl       = Move{Name: "growl", Type: "normal", Accuracy: 100, PP: 40, Class: Status, StatChanges: []StatChange{{Stat: "attack", Change: -1}}}
+    swordsDance = Move{Name: "swords-dance", Type: "normal", PP: 20, Class: Status, StatChanges: []StatChange{{Stat: "attack", Change: 2}}, StatsOnSelf: true}
+)
+
+func newStatusBattle(a, z *Combatant, log *bytes.Buffer) *Battle {
+    return New(NewSide("a", a), NewSide("z", z), rand.New(rand.NewSource(1)), testChart, log)
+}
+
+func TestStageMultiplier(t *testing.T) {
+    cases := map[int]float64{0: 1, 1: 1.5, 2: 2, 6: 4, -1: 2.0 / 3, -2: 0.5, -6: 0.25}
+    for stage, want := range cases {
+        if got := stageMultiplier(stage); got != want {
+            t.Fatalf("stageMultiplier(%d) = %v, want %v", stage, got, want)
+        }
+    }
+}
+
+func TestStatChangesAreLoggedAndClamped(t *testing.T) {
+    log := &bytes.Buffer{}
+    a := NewCombatant("a", 50, nil, Stats{HP: 100, Attack: 50, Defense: 50, Speed: 60}, []Move{swordsDance, growl})
+    z := NewCombatant("z", 50, nil, Stats{HP: 100, Attack: 50, Defense: 50, Speed: 10}, []Move{swordsDance, growl})
+    b := newStatusBattle(a, z, log)
+
+    before := baseDamage(a, z, tackle)
+    for i := 0; i < 4; i++ {
+        b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    }
+    if a.Stages["attack"] != maxStage {
+        t.Fatalf("expected attack stage to cap at %d, got %d", maxStage, a.Stages["attack"])
+    }
+    if baseDamage(a, z, tackle) <= before {
+        t.Fatalf("expected raised attack to increase damage")
+    }
+    got := log.String()
+    if !strings.Contains(got, "a's attack rose sharply!") || !strings.Contains(got, "a's attack won't go any higher!") {
+        t.Fatalf("missing stat change lines:\n%s", got)
+    }
+
+    b.PlayTurn([2]Action{MoveAction(1), MoveAction(1)})
+    if z.Stages["attack"] != maxStage-1 || a.Stages["attack"] != maxStage-1 || !strings.Contains(log.String(), "z's attack fell!") {
+        t.Fatalf("expected growl to lower z's attack, stages %v\n%s", z.Stages, log.String())
+    }
+}
+
+func TestPoisonDamagesAtEndOfTurn(t *testing.T) {
+    log := &bytes.Buffer{}
+    a := NewCombatant("a", 50, nil, Stats{HP: 80, Attack: 50, Defense: 50, Speed: 60}, []Move{toxic})
+    z := NewCombatant("z", 50, nil, Stats{HP: 80, Attack: 50, Defense: 50, Speed: 10}, []Move{growl})
+    b := newStatusBattle(a, z, log)
+
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    if z.Status != Poison || z.HP != 70 {
+        t.Fatalf("expected z poisoned and down to 70 HP, got %q %d", z.Status, z.HP)
+    }
+    got := log.String()
+    if !strings.Contains(got, "z was poisoned!") || !strings.Contains(got, "z is hurt by poison!") {
+        t.Fatalf("missing poison lines:\n%s", got)
+    }
+
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    if !strings.Contains(log.String(), "But it failed!") {
+        t.Fatalf("expected a second poisoning to fail:\n%s", log.String())
+    }
+}
+
+func TestStatusImmunities(t *testing.T) {
+    log := &bytes.Buffer{}
+    a := NewCombatant("a", 50, nil, Stats{HP: 80, Speed: 60}, []Move{toxic})
+    z := NewCombatant("z", 50, []string{"poison"}, Stats{HP: 80, Speed: 10}, []Move{growl})
+    b := newStatusBattle(a, z, log)
+
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    if z.Status != "" || !strings.Contains(log.String(), "It doesn't affect z...") {
+        t.Fatalf("expected poison type to be immune:\n%s", log.String())
+    }
+}
+
+func TestParalysisHalvesSpeedAndCanStopMoves(t *testing.T) {
+    log := &bytes.Buffer{}
+    z := NewCombatant("z", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 100}, []Move{tackle})
+    z.Status = Paralysis
+    if z.Speed() != 50 {
+        t.Fatalf("expected paralysis to halve speed, got %d", z.Speed())
+    }
+    a := NewCombatant("a", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 10}, []Move{tackle})
+    b := newStatusBattle(a, z, log)
+    for i := 0; i < 20; i++ {
+        b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    }
+    if !strings.Contains(log.String(), "z is fully paralyzed!") {
+        t.Fatalf("expected z to be fully paralyzed at least once in 20 turns:\n%s", log.String())
+    }
+}
+
+func TestSleepWearsOff(t *testing.T) {
+    log := &bytes.Buffer{}
+    a := NewCombatant("a", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 10}, []Move{tackle})
+    z := NewCombatant("z", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 100}, []Move{tackle})
+    b := newStatusBattle(a, z, log)
+    b.inflict(a, Sleep, true)
+
+    for i := 0; i < 5; i++ {
+        b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    }
+    got := log.String()
+    if !strings.Contains(got, "a fell asleep!") || !strings.Contains(got, "a is fast asleep.") || !strings.Contains(got, "a woke up!") {
+        t.Fatalf("missing sleep lines:\n%s", got)
+    }
+    if a.Status != "" {
+        t.Fatalf("expected a to be awake, got %q", a.Status)
+    }
+}
+
+func TestConfusionEndsAndClearsOnSwitch(t *testing.T) {
+    log := &bytes.Buffer{}
+    a := NewCombatant("a", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 10}, []Move{tackle})
+    bench := NewCombatant("bench", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 10}, []Move{tackle})
+    z := NewCombatant("z", 50, nil, Stats{HP: 500, Attack: 50, Defense: 50, Speed: 100}, []Move{tackle})
+    b := New(NewSide("a", a, bench), NewSide("z", z), rand.New(rand.NewSource(1)), nil, log)
+
+    b.inflict(a, Confusion, true)
+    b.changeStage(a, StatChange{Stat: "defense", Change: 1})
+    if !a.Confused() || StatusLabel(a) != "CNF" {
+        t.Fatalf("expected a to be confused, label %q", StatusLabel(a))
+    }
+    b.PlayTurn([2]Action{SwitchAction(1), MoveAction(0)})
+    if a.Confused() || a.Stages["defense"] != 0 {
+        t.Fatalf("expected switching out to clear confusion and stages")
+    }
+
+    b.inflict(bench, Confusion, true)
+    for i := 0; i < 6; i++ {
+        b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    }
+    if bench.Confused() || !strings.Contains(log.String(), "bench snapped out of its confusion!") {
+        t.Fatalf("expected confusion to wear off:\n%s", log.String())
+    }
+}
+
+func TestBurnHalvesPhysicalDamage(t *testing.T) {
+    a := NewCombatant("a", 50, nil, Stats{HP: 100, Attack: 100, Defense: 100}, nil)
+    z := NewCombatant("z", 50, nil, Stats{HP: 100, Attack: 100, Defense: 100}, nil)
+    healthy := baseDamage(a, z, tackle)
+    a.Status = Burn
+    if got := baseDamage(a, z, tackle); got >= healthy {
+        t.Fatalf("expected burn to reduce physical damage: %v >= %v", got, healthy)
+    }
+}
+
+func TestSecondaryAilmentFromDamagingMove(t *testing.T) {
+    log := &bytes.Buffer{}
+    ember := Move{Name: "ember", Type: "fire", Power: 40, Accuracy: 100, PP: 25, Class: Special, Ailment: Burn, AilmentChance: 100}
+    a := NewCombatant("a", 50, nil, Stats{HP: 100, SpAttack: 10, SpDefense: 100, Speed: 60}, []Move{ember})
+    z := NewCombatant("z", 50, nil, Stats{HP: 100, SpAttack: 10, SpDefense: 100, Speed: 10}, []Move{thunderWave})
+    b := newStatusBattle(a, z, log)
+    b.PlayTurn([2]Action{MoveAction(0), MoveAction(0)})
+    if z.Status != Burn || !strings.Contains(log.String(), "z was burned!") || !strings.Contains(log.String(), "z is hurt by its burn!") {
+        t.Fatalf("expected ember to burn z:\n%s", log.String())
+    }
+}
@@ -0,0 +1,456 @@
+package cli
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "io"
+    "math/rand"
+    "sort"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/battle"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
+
+const maxMoveLookups = 8
+
+// defaultTeamAI switches out of bad matchups, which makes team battles
+// more than a straight slugfest.
+const defaultTeamAI = "typeaware"
+
+var defaultMove = battle.Move{Name: "tackle", Type: "normal", Power: 40, Accuracy: 100, PP: 35, Class: battle.Physical}
+
+func (c *CLI) cmdBattle(ctx context.Context, args []string) {
+    if len(args) > 0 && args[0] == "team" {
+        c.cmdTeamBattle(ctx, args[1:])
+        return
+    }
+    interactive := false
+    aiName, rest, ok := c.parseAIFlag(args, battle.DefaultAI)
+    if !ok {
+        return
+    }
+    var names []string
+    for _, arg := range rest {
+        switch arg {
+        case "-i", "--interactive":
+            interactive = true
+        default:
+            names = append(names, arg)
+        }
+    }
+    if len(names) < 2 || !validTeamArgs(names[:2]) {
+        fmt.Fprintln(c.out, "usage: battle [--interactive] [--ai <strategy>] <pokemon1>[,<pokemon>...] <pokemon2>[,<pokemon>...]")
+        fmt.Fprintln(c.out, "       battle team [--ai <strategy>] <party1> <party2>")
+        return
+    }
+    opponent, err := c.newAI(aiName)
+    if err != nil {
+        return
+    }
+
+    teams := [2][]store.Entry{}
+    for i, arg := range names[:2] {
+        team, ok := c.teamEntries(strings.Split(arg, ","))
+        if !ok {
+            return
+        }
+        teams[i] = team
+    }
+
+    sideNames := [2]string{names[0], names[1]}
+    if interactive {
+        sideNames = [2]string{"Player", "Opponent"}
+    }
+    controllers := [2]battle.Controller{battle.RandomAI{Rng: c.rng}, opponent}
+    if interactive {
+        controllers[0] = &promptController{c: c, ctx: ctx}
+    }
+    c.runBattle(ctx, fmt.Sprintf("Battle: %s vs %s", names[0], names[1]), sideNames, teams, controllers)
+}
+
+// cmdTeamBattle pits two saved parties against each other, both driven by
+// the same AI strategy.
+func (c *CLI) cmdTeamBattle(ctx context.Context, args []string) {
+    aiName, names, ok := c.parseAIFlag(args, defaultTeamAI)
+    if !ok {
+        return
+    }
+    if len(names) < 2 {
+        fmt.Fprintln(c.out, "usage: battle team [--ai <strategy>] <party1> <party2>")
+        return
+    }
+    ai, err := c.newAI(aiName)
+    if err != nil {
+        return
+    }
+
+    teams := [2][]store.Entry{}
+    for i, name := range names[:2] {
+        party, ok := c.store.Party(name)
+        if !ok {
+            fmt.Fprintf(c.out, "you have no party named %s\n", name)
+            return
+        }
+        team, ok := c.teamEntries(party.Members)
+        if !ok {
+            return
+        }
+        teams[i] = team
+    }
+
+    sideNames := [2]string{names[0], names[1]}
+    bt, ok := c.runBattle(ctx, fmt.Sprintf("Team battle: %s vs %s", names[0], names[1]), sideNames, teams, [2]battle.Controller{ai, ai})
+    if ok {
+        c.printBattleSummary(bt)
+    }
+}
+
+// parseAIFlag pulls "--ai <strategy>" out of args.
+func (c *CLI) parseAIFlag(args []string, def string) (string, []string, bool) {
+    name := def
+    var rest []string
+    for i := 0; i < len(args); i++ {
+        if args[i] != "--ai" {
+            rest = append(rest, args[i])
+            continue
+        }
+        if i+1 == len(args) {
+            fmt.Fprintf(c.out, "--ai needs a strategy: %s\n", strings.Join(battle.AINames(), ", "))
+            return "", nil, false
+        }
+        i++
+        name = args[i]
+    }
+    return name, rest, true
+}
+
+func (c *CLI) newAI(name string) (battle.BattleAI, error) {
+    ai, err := battle.NewAI(name, c.rng)
+    if err != nil {
+        fmt.Fprintf(c.out, "%v; available strategies: %s\n", err, strings.Join(battle.AINames(), ", "))
+    }
+    return ai, err
+}
+
+// validTeamArgs reports whether every comma-separated side names at least
+// one Pokémon and has no empty names, as in "pikachu,,onix".
+func validTeamArgs(args []string) bool {
+    for _, arg := range args {
+        for _, name := range strings.Split(arg, ",") {
+            if name == "" {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+func (c *CLI) teamEntries(names []string) ([]store.Entry, bool) {
+    if len(names) == 0 {
+        fmt.Fprintln(c.out, "a party needs at least one pokemon")
+        return nil, false
+    }
+    var team []store.Entry
+    for _, name := range names {
+        e, ok := c.owned(name)
+        if !ok {
+            return nil, false
+        }
+        team = append(team, e)
+    }
+    if len(team) > store.MaxPartySize {
+        fmt.Fprintf(c.out, "a party can have at most %d pokemon\n", store.MaxPartySize)
+        return nil, false
+    }
+    return team, true
+}
+
+// runBattle builds both sides, plays the battle out on its own seeded rng,
+// rewards the winners and records a replay. It reports false if the battle could not be set up.
+func (c *CLI) runBattle(ctx context.Context, title string, sideNames [2]string, teams [2][]store.Entry, controllers [2]battle.Controller) (*battle.Battle, bool) {
+    var sides [2]*battle.Side
+    for i, team := range teams {
+        side, err := c.battleSide(ctx, sideNames[i], team)
+        if err != nil {
+            c.printErr(err)
+            return nil, false
+        }
+        sides[i] = side
+    }
+
+    fmt.Fprintln(c.out, title)
+    bt, res, rep := c.playBattle(sides, controllers, c.out)
+
+    switch res.Winner {
+    case battle.Draw:
+        if bt.Over() {
+            fmt.Fprintln(c.out, "It's a draw!")
+        } else {
+            fmt.Fprintln(c.out, "battle ended in a draw")
+        }
+    default:
+        fmt.Fprintf(c.out, "%s wins!\n", sides[res.Winner].Name)
+    }
+    c.settleBattle(ctx, sides, teams, res.Winner, rep)
+    c.saveLadder()
+    return bt, true
+}
+
+// playBattle runs a battle on its own seeded rng, echoing the log to w
+// when it is not nil, and returns the replay of it.
+func (c *CLI) playBattle(sides [2]*battle.Side, controllers [2]battle.Controller, w io.Writer) (*battle.Battle, battle.Result, *battle.Replay) {
+    seed := c.rng.Int63()
+    log := &bytes.Buffer{}
+    var out io.Writer = log
+    if w != nil {
+        out = io.MultiWriter(w, log)
+    }
+    bt := battle.New(sides[0], sides[1], rand.New(rand.NewSource(seed)), c.typeChart(), out)
+    rep := battle.NewReplay(seed, bt, [2]string{controllerName(controllers[0]), controllerName(controllers[1])})
+    recorders := [2]*battle.Recorder{{Controller: controllers[0]}, {Controller: controllers[1]}}
+    res := bt.Run([2]battle.Controller{recorders[0], recorders[1]})
+    rep.Finish(recorders, res, log.String())
+    return bt, res, rep
+}
+
+// settleBattle rewards the winners' survivors, rates the battle and
+// records its replay. The caller saves the ladder.
+func (c *CLI) settleBattle(ctx context.Context, sides [2]*battle.Side, teams [2][]store.Entry, winner int, rep *battle.Replay) {
+    if winner != battle.Draw {
+        xp, effort := 0, make(map[string]int)
+        for i, member := range sides[1-winner].Team {
+            if member.Fainted() {
+                defeated := teams[1-winner][i]
+                xp += defeated.ExperienceYield()
+                for stat, ev := range defeated.EffortYield {
+                    effort[stat] += ev
+                }
+            }
+        }
+        for i, member := range sides[winner].Team {
+            if !member.Fainted() {
+                c.rewardWinner(ctx, teams[winner][i], xp, effort)
+            }
+        }
+    }
+    c.rate(teams, winner)
+    c.saveReplay(rep)
+}
+
+func controllerName(ctl battle.Controller) string {
+    switch ctl := ctl.(type) {
+    case battle.BattleAI:
+        return ctl.Name()
+    case *promptController:
+        return "interactive"
+    }
+    return "unknown"
+}
+
+func (c *CLI) printBattleSummary(bt *battle.Battle) {
+    fmt.Fprintf(c.out, "Summary after %d turns:\n", bt.Turn)
+    for _, side := range bt.Sides {
+        fmt.Fprintf(c.out, "  %s\n", side.Name)
+        for _, member := range side.Team {
+            state := fmt.Sprintf("%d/%d HP", member.HP, member.Stats.HP)
+            if member.Fainted() {
+                state = "fainted"
+            }
+            fmt.Fprintf(c.out, "    %-12s KOs: %d  damage dealt: %-5d %s\n", member.Name, member.KOs, member.DamageDealt, state)
+        }
+    }
+}
+
+func (c *CLI) battleSide(ctx context.Context, name string, team []store.Entry) (*battle.Side, error) {
+    var types []string
+    for _, e := range team {
+        types = append(types, e.Types...)
+    }
+    if err := c.loadTypes(ctx, types...); err != nil {
+        if ctx.Err() != nil {
+            return nil, ctx.Err()
+        }
+        fmt.Fprintf(c.out, "type data unavailable (%v); ignoring type matchups\n", err)
+    }
+    side := battle.NewSide(name)
+    for _, e := range team {
+        member, err := c.combatant(ctx, e)
+        if err != nil {
+            return nil, err
+        }
+        side.Team = append(side.Team, member)
+    }
+    return side, nil
+}
+
+func (c *CLI) typeChart() battle.TypeChart {
+    if c.types == nil {
+        return nil
+    }
+    return c.types
+}
+
+// rewardWinner gives a surviving winner xp experience, the effort values
+// of the Pokémon it defeated and a little happiness.
+func (c *CLI) rewardWinner(ctx context.Context, winner store.Entry, xp int, effort map[string]int) {
+    rate, err := c.growthRate(ctx, winner)
+    if err != nil {
+        if ctx.Err() != nil {
+            return
+        }
+        fmt.Fprintf(c.out, "growth rate unavailable (%v); using medium-fast\n", err)
+        rate = mediumFast
+    }
+    gained := 0
+    e, ok := c.store.Update(winner.ID, func(e *store.Entry) {
+        gained = e.GainExperience(xp, rate)
+        e.GainEffort(effort)
+        e.GrowthRate = rate.Name
+        e.Happiness += 5
+        if e.Happiness > store.MaxHappiness { e.Happiness = store.MaxHappiness }
+    })
+    if !ok {
+        return
+    }
+    fmt.Fprintf(c.out, "%s gained %d experience.\n", e.DisplayName(), xp)
+    if gained > 0 {
+        fmt.Fprintf(c.out, "%s grew to level %d!\n", e.DisplayName(), e.Level)
+    }
+}
+
+// growthRate looks up the experience curve of e's species.
+func (c *CLI) growthRate(ctx context.Context, e store.Entry) (*api.GrowthRate, error) {
+    name := e.GrowthRate
+    if name == "" {
+        s, err := c.api.FetchPokemonSpecies(ctx, e.SpeciesName())
+        if err != nil {
+            return nil, err
+        }
+        name = s.GrowthRate
+    }
+    return c.api.FetchGrowthRate(ctx, name)
+}
+
+// mediumFast is the most common growth rate, where level n needs n³
+// experience.
+var mediumFast = func() *api.GrowthRate {
+    g := &api.GrowthRate{Name: "medium-fast"}
+    for n := 1; n <= store.MaxLevel; n++ {
+        g.Levels = append(g.Levels, api.GrowthLevel{Level: n, Experience: n * n * n})
+    }
+    g.Levels[0].Experience = 0
+    return g
+}()
+
+func (c *CLI) combatant(ctx context.Context, e store.Entry) (*battle.Combatant, error) {
+    moves, err := c.moveset(ctx, e)
+    if err != nil {
+        return nil, err
+    }
+    final := e.FinalStats()
+    stats := battle.Stats{
+        HP:        final["hp"],
+        Attack:    final["attack"],
+        Defense:   final["defense"],
+        SpAttack:  final["special-attack"],
+        SpDefense: final["special-defense"],
+        Speed:     final["speed"],
+    }
+    return battle.NewCombatant(e.DisplayName(), e.Level, e.Types, stats, moves), nil
+}
+
+// moveset picks up to four damaging or status-inflicting moves, preferring the most recently
+// learned level-up moves, the same way a wild Pokémon's moves are chosen.
+// Saved entries carry no learnset, so it is fetched through the cache.
+func (c *CLI) moveset(ctx context.Context, e store.Entry) ([]battle.Move, error) {
+    learnset := e.Moves
+    if len(learnset) == 0 {
+        p, err := c.api.FetchPokemon(ctx, e.Name)
+        if err == nil {
+            learnset = p.Moves
+        } else if ctx.Err() != nil {
+            return nil, ctx.Err()
+        }
+    }
+    candidates := levelUpMoves(learnset, e.Level)
+    var moves []battle.Move
+    for i, name := range candidates {
+        if len(moves) == 4 || i == maxMoveLookups {
+            break
+        }
+        m, err := c.api.FetchMove(ctx, name)
+        if err != nil {
+            if ctx.Err() != nil {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+        if bm := toBattleMove(m); bm.Power > 0 || bm.Ailment != "" || len(bm.StatChanges) > 0 {
+            moves = append(moves, bm)
+        }
+    }
+    if len(moves) == 0 {
+        moves = append(moves, defaultMove)
+    }
+    return moves, nil
+}
+
+func levelUpMoves(learnset []api.PokemonMove, level int) []string {
+    learnedAt := make(map[string]int)
+    for _, m := range learnset {
+        if m.LearnMethod != "level-up" || m.LevelLearned > level {
+            continue
+        }
+        if cur, ok := learnedAt[m.Name]; !ok || m.LevelLearned > cur {
+            learnedAt[m.Name] = m.LevelLearned
+        }
+    }
+    names := make([]string, 0, len(learnedAt))
+    for n := range learnedAt {
+        names = append(names, n)
+    }
+    sort.Slice(names, func(i, j int) bool {
+        if learnedAt[names[i]] != learnedAt[names[j]] {
+            return learnedAt[names[i]] > learnedAt[names[j]]
+        }
+        return names[i] < names[j]
+    })
+    return names
+}
+
+var selfTargets = map[string]bool{
+    "user":            true,
+    "user-and-allies": true,
+    "user-or-ally":    true,
+    "all-allies":      true,
+}
+
+// toBattleMove keeps only the ailments the battle engine models. Stat
+// changes land on the user for moves aimed at it or its allies (there are no
+// allies in a single battle) and for damaging moves that boost (or, like
+// close-combat, drop) the attacker's own stats.
+func toBattleMove(m *api.Move) battle.Move {
+    bm := battle.Move{
+        Name:          m.Name,
+        Type:          m.Type,
+        Power:         m.Power,
+        Accuracy:      m.Accuracy,
+        PP:            m.PP,
+        Priority:      m.Priority,
+        Class:         m.DamageClass,
+        AilmentChance: m.AilmentChance,
+        StatChance:    m.StatChance,
+        StatsOnSelf:   selfTargets[m.Target] || m.Category == "damage+raise",
+    }
+    switch m.Ailment {
+    case battle.Poison, battle.Burn, battle.Paralysis, battle.Sleep, battle.Freeze, battle.Confusion:
+        bm.Ailment = m.Ailment
+    }
+    for _, sc := range m.StatChanges {
+        bm.StatChanges = append(bm.StatChanges, battle.StatChange{Stat: sc.Stat, Change: sc.Change})
+    }
+    return bm
+}
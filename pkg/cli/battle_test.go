@@ -1,26 +1,27 @@
 package cli
 
 import (
-    "bytes"
-    "math/rand"
+    "context"
+    "os"
+    "path/filepath"
     "strings"
     "testing"
 
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/battle"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/ladder"
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
 )
 
 func TestBattleDeterministic(t *testing.T) {
-    out := &bytes.Buffer{}
-    rng := rand.New(rand.NewSource(1))
-    c := &CLI{in: nil, out: out, store: store.NewStore(), rng: rng}
+    c, out := newTestCLI(t, nil)
 
     weak := api.Pokemon{Name: "weak", Stats: map[string]int{"hp": 10, "attack": 1, "defense": 1, "speed": 10}}
     strong := api.Pokemon{Name: "strong", Stats: map[string]int{"hp": 100, "attack": 1000, "defense": 1, "speed": 5}}
     c.store.Add(weak)
     c.store.Add(strong)
 
-    c.cmdBattle([]string{"weak", "strong"})
+    c.cmdBattle(context.Background(), []string{"weak", "strong"})
     got := out.String()
     if !strings.Contains(got, "Battle: weak vs strong") {
         t.Fatalf("unexpected output, missing header: %s", got)
@@ -29,3 +30,161 @@ func TestBattleDeterministic(t *testing.T) {
         t.Fatalf("expected strong to win; output:\n%s", got)
     }
 }
+
+func TestBattleAppliesTypeEffectiveness(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/move/water-gun/": `{"name": "water-gun", "power": 40, "accuracy": 100, "pp": 25, "type": {"name": "water"}, "damage_class": {"name": "special"}}`,
+        "/move/ember/":     `{"name": "ember", "power": 40, "accuracy": 100, "pp": 25, "type": {"name": "fire"}, "damage_class": {"name": "special"}}`,
+        "/move/growl/":     `{"name": "growl", "power": null, "accuracy": 100, "pp": 40, "type": {"name": "normal"}, "damage_class": {"name": "status"}}`,
+    })
+    c.types = api.NewTypeChart()
+    c.types.Add(&api.Type{Name: "water", DamageRelations: api.DamageRelations{
+        DoubleDamageTo: []string{"fire"}, HalfDamageFrom: []string{"fire"},
+    }})
+    c.types.Add(&api.Type{Name: "fire", DamageRelations: api.DamageRelations{
+        HalfDamageTo: []string{"water"}, DoubleDamageFrom: []string{"water"},
+    }})
+
+    stats := map[string]int{"hp": 200, "attack": 50, "defense": 50, "special-attack": 50, "special-defense": 50, "speed": 50}
+    learn := func(name string) api.PokemonMove {
+        return api.PokemonMove{Name: name, LearnMethod: "level-up", LevelLearned: 1, VersionGroup: "red-blue"}
+    }
+    c.store.Add(api.Pokemon{Name: "squirtle", Stats: stats, Types: []string{"water"}, Moves: []api.PokemonMove{learn("water-gun"), learn("growl")}})
+    c.store.Add(api.Pokemon{Name: "charmander", Stats: stats, Types: []string{"fire"}, Moves: []api.PokemonMove{learn("ember")}})
+
+    c.cmdBattle(context.Background(), []string{"squirtle", "charmander"})
+    got := out.String()
+    if !strings.Contains(got, "squirtle used water-gun!") || strings.Contains(got, "used growl") {
+        t.Fatalf("expected squirtle to battle with damaging moves only; output:\n%s", got)
+    }
+    if !strings.Contains(got, "It's super effective!") || !strings.Contains(got, "It's not very effective...") {
+        t.Fatalf("expected effectiveness announcements; output:\n%s", got)
+    }
+    if !strings.Contains(got, "squirtle wins!") {
+        t.Fatalf("expected the water type to win an even matchup; output:\n%s", got)
+    }
+}
+
+func TestMovesetFetchesLearnsetOfSavedPokemon(t *testing.T) {
+    c, _ := newTestCLI(t, map[string]string{
+        "/pokemon/pikachu/":     testPikachuMovesJSON,
+        "/move/thunder-shock/": `{"name": "thunder-shock", "power": 40, "accuracy": 100, "pp": 30, "type": {"name": "electric"}, "damage_class": {"name": "special"}}`,
+    })
+    path := filepath.Join(t.TempDir(), "save.json")
+    s, err := store.Open(path)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    s.Add(api.Pokemon{Name: "pikachu", Moves: []api.PokemonMove{{Name: "thunder-shock", LearnMethod: "level-up", LevelLearned: 1}}})
+    if data, _ := os.ReadFile(path); strings.Contains(string(data), "thunder-shock") {
+        t.Fatalf("expected the learnset to stay out of the save file:\n%s", data)
+    }
+
+    c.store, err = store.Open(path)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    e, _ := c.store.Get("pikachu")
+    moves, err := c.moveset(context.Background(), e)
+    if err != nil || len(moves) != 1 || moves[0].Name != "thunder-shock" {
+        t.Fatalf("expected the learnset to be fetched again, got %+v (%v)", moves, err)
+    }
+}
+
+func TestLevelUpMovesPrefersRecentMoves(t *testing.T) {
+    learnset := []api.PokemonMove{
+        {Name: "tackle", LearnMethod: "level-up", LevelLearned: 1},
+        {Name: "vine-whip", LearnMethod: "level-up", LevelLearned: 9},
+        {Name: "vine-whip", LearnMethod: "level-up", LevelLearned: 10},
+        {Name: "razor-leaf", LearnMethod: "level-up", LevelLearned: 20},
+        {Name: "solar-beam", LearnMethod: "machine"},
+    }
+    got := levelUpMoves(learnset, 12)
+    if len(got) != 2 || got[0] != "vine-whip" || got[1] != "tackle" {
+        t.Fatalf("unexpected level-up moves %v", got)
+    }
+}
+
+func TestBattleSelectsOpponentAI(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    c.store.Add(api.Pokemon{Name: "weak", Stats: map[string]int{"hp": 10, "attack": 1, "defense": 1, "speed": 10}})
+    c.store.Add(api.Pokemon{Name: "strong", Stats: map[string]int{"hp": 100, "attack": 1000, "defense": 1, "speed": 5}})
+
+    c.cmdBattle(context.Background(), []string{"--ai", "minimax", "weak", "strong"})
+    if got := out.String(); !strings.Contains(got, "strong wins!") {
+        t.Fatalf("expected strong to win with the minimax AI; output:\n%s", got)
+    }
+
+    out.Reset()
+    c.cmdBattle(context.Background(), []string{"--ai", "clairvoyant", "weak", "strong"})
+    got := out.String()
+    if !strings.Contains(got, `unknown AI "clairvoyant"`) || !strings.Contains(got, "greedy, minimax, random, typeaware") {
+        t.Fatalf("expected unknown AI message listing strategies, got %q", got)
+    }
+}
+
+func TestToBattleMoveCarriesEffects(t *testing.T) {
+    m := toBattleMove(&api.Move{Name: "swords-dance", PP: 20, DamageClass: "status", Target: "user",
+        StatChanges: []api.MoveStatChange{{Stat: "attack", Change: 2}}})
+    if !m.StatsOnSelf || len(m.StatChanges) != 1 || m.StatChanges[0].Change != 2 {
+        t.Fatalf("expected swords-dance to raise the user's attack: %+v", m)
+    }
+
+    m = toBattleMove(&api.Move{Name: "howl", PP: 40, DamageClass: "status", Target: "user-and-allies",
+        StatChanges: []api.MoveStatChange{{Stat: "attack", Change: 1}}})
+    if !m.StatsOnSelf {
+        t.Fatalf("expected howl to raise the user's attack: %+v", m)
+    }
+
+    m = toBattleMove(&api.Move{Name: "bind", Power: 15, Ailment: "trap", AilmentChance: 100, Target: "selected-pokemon"})
+    if m.Ailment != "" || m.StatsOnSelf {
+        t.Fatalf("expected unsupported ailments to be dropped: %+v", m)
+    }
+
+    m = toBattleMove(&api.Move{Name: "thunderbolt", Power: 90, Ailment: "paralysis", AilmentChance: 10})
+    if m.Ailment != battle.Paralysis || m.AilmentChance != 10 {
+        t.Fatalf("expected paralysis chance to carry over: %+v", m)
+    }
+}
+
+func TestBattleAwardsExperienceAlongGrowthRate(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon-species/strong/": `{"name": "strong", "growth_rate": {"name": "fast"}}`,
+        "/growth-rate/fast/":       `{"name": "fast", "levels": [{"level": 5, "experience": 100}, {"level": 6, "experience": 140}, {"level": 7, "experience": 200}]}`,
+    })
+    c.store.Add(api.Pokemon{Name: "weak", BaseExperience: 64, EffortYield: map[string]int{"hp": 1}, Stats: map[string]int{"hp": 10, "attack": 1, "defense": 1, "speed": 1}})
+    c.store.Add(api.Pokemon{Name: "strong", Stats: map[string]int{"hp": 100, "attack": 300, "defense": 50, "speed": 50}})
+
+    c.cmdBattle(context.Background(), []string{"strong", "weak"})
+    got := out.String()
+    if !strings.Contains(got, "strong gained 45 experience.") || !strings.Contains(got, "strong grew to level 6!") {
+        t.Fatalf("expected experience and a level-up:\n%s", got)
+    }
+    e, _ := c.store.Get("strong")
+    if e.Level != 6 || e.Experience != 145 || e.GrowthRate != "fast" || e.EVs["hp"] != 1 {
+        t.Fatalf("unexpected progress after battle: %+v", e)
+    }
+}
+
+func TestBattleRejectsEmptySides(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    c.ladder = ladder.New(filepath.Join(t.TempDir(), "ladder.json"))
+    c.store.Add(api.Pokemon{Name: "pikachu", Stats: map[string]int{"hp": 35, "attack": 55, "defense": 40, "speed": 90}})
+    c.store.Add(api.Pokemon{Name: "onix", Stats: map[string]int{"hp": 35, "attack": 45, "defense": 160, "speed": 70}})
+
+    for _, args := range [][]string{{",", "pikachu"}, {"pikachu,,onix", "pikachu"}} {
+        out.Reset()
+        c.cmdBattle(context.Background(), args)
+        if got := out.String(); !strings.HasPrefix(got, "usage: battle") {
+            t.Fatalf("expected usage for %q, got:\n%s", args, got)
+        }
+        out.Reset()
+        c.cmdSimulate(context.Background(), args)
+        if got := out.String(); !strings.HasPrefix(got, "usage: simulate") {
+            t.Fatalf("expected usage for %q, got:\n%s", args, got)
+        }
+    }
+    if len(c.ladder.Rankings()) != 0 {
+        t.Fatalf("expected no rated battles")
+    }
+}
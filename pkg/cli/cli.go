@@ -2,16 +2,23 @@ package cli
 
 import (
     "bufio"
+    "context"
+    "errors"
     "fmt"
     "io"
     "math/rand"
+    "net/http"
     "os"
+    "os/signal"
+    "path/filepath"
     "sort"
     "strings"
+    "sync"
     "time"
 
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/internal/pokecache"
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/ladder"
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
 )
 
@@ -19,13 +26,30 @@ type CLI struct {
     in  io.Reader
     out io.Writer
     store *store.Store
+    api *api.Client
     pager struct{
         Next *string
         Previous *string
     }
+    scanner *bufio.Scanner
     rng *rand.Rand
+    now func() time.Time
+    types *api.TypeChart
+    savePath string
+    replayDir string
+    ladder *ladder.Ladder
+    // location is the last area explored, recorded on each catch.
+    location string
+    shinyChance float64
+
+    mu     sync.Mutex
+    cancel context.CancelFunc
 }
 
+// rawArgCommands take file paths or nicknames, so their arguments keep
+// their case.
+var rawArgCommands = map[string]bool{"replay": true, "nickname": true}
+
 var ballModifiers = map[string]float64{
     "pokeball": 1.0,
     "greatball": 1.5,
@@ -33,25 +57,106 @@ var ballModifiers = map[string]float64{
     "masterball": 100.0,
 }
 
-func Run(in io.Reader, out io.Writer) {
-    c := &CLI{in: in, out: out, store: store.NewStore()}
+// DefaultShinyChance is the odds of a catch being shiny.
+const DefaultShinyChance = 1.0 / 4096
+
+const shinyMarker = "★"
+
+type Option func(*CLI)
+
+// WithSavePath keeps the Pokédex in path instead of save.json in the data
+// directory. Ladder and replays are stored next to it.
+func WithSavePath(path string) Option {
+    return func(c *CLI) { c.savePath = path }
+}
+
+// WithShinyChance sets the probability, between 0 and 1, that a caught
+// Pokémon is shiny.
+func WithShinyChance(p float64) Option {
+    return func(c *CLI) { c.shinyChance = p }
+}
+
+// dataDir is where the Pokedex keeps files between sessions, following the
+// XDG base directory spec.
+func dataDir() string {
+    if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+        return filepath.Join(dir, "pokedex")
+    }
+    home, err := os.UserHomeDir()
+    if err != nil {
+        return ""
+    }
+    return filepath.Join(home, ".local", "share", "pokedex")
+}
+
+// Run starts the REPL. It returns an error if the save file cannot be
+// loaded or input cannot be read.
+func Run(in io.Reader, out io.Writer, opts ...Option) error {
+    c := &CLI{in: in, out: out, shinyChance: DefaultShinyChance}
+    for _, opt := range opts {
+        opt(c)
+    }
 
     if c.rng == nil {
         c.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
     }
 
-    api.Cache = pokecache.NewCache(5 * 1000000000)
+    if c.savePath == "" {
+        if dir := dataDir(); dir != "" {
+            c.savePath = filepath.Join(dir, "save.json")
+        }
+    }
+    if c.savePath == "" {
+        fmt.Fprintln(c.out, "no data directory found; your Pokédex will not be saved")
+        c.store = store.NewStore()
+    } else {
+        s, err := store.Open(c.savePath, store.WithSaveErrorHandler(func(err error) {
+            fmt.Fprintf(c.out, "could not save your Pokédex: %v\n", err)
+        }))
+        if err != nil {
+            return fmt.Errorf("could not load your Pokédex: %w", err)
+        }
+        c.store = s
+
+        dir := filepath.Dir(c.savePath)
+        c.replayDir = filepath.Join(dir, "replays")
+        l, err := ladder.Load(filepath.Join(dir, "ladder.json"))
+        if err != nil {
+            fmt.Fprintf(c.out, "could not load ladder, ratings are off this session: %v\n", err)
+        }
+        c.ladder = l
+        if l != nil {
+            c.migrateLadder()
+        }
+    }
+
+    if c.api == nil {
+        c.api = api.NewClient(
+            api.WithCache(pokecache.NewCache(5 * time.Second)),
+            api.WithRetryPolicy(api.DefaultRetryPolicy()),
+            api.WithRetryHook(c.onRetry),
+        )
+    }
+
+    interrupts := make(chan os.Signal, 1)
+    signal.Notify(interrupts, os.Interrupt)
+    defer signal.Stop(interrupts)
+    go func() {
+        for range interrupts {
+            c.interrupt()
+        }
+    }()
 
-    scanner := bufio.NewScanner(c.in)
     for {
         fmt.Fprint(c.out, "Pokedex > ")
-        if !scanner.Scan() {
-            if err := scanner.Err(); err != nil {
-                fmt.Fprintln(os.Stderr, "error reading input:", err)
+        line, ok := c.readLine()
+        if !ok {
+            if err := c.scanner.Err(); err != nil {
+                return fmt.Errorf("error reading input: %w", err)
             }
-            break
+            return nil
         }
-        words := cleanInput(scanner.Text())
+        words := cleanInput(line)
         if len(words) == 0 {
             continue
         }
@@ -60,33 +165,131 @@ func Run(in io.Reader, out io.Writer) {
         if len(words) > 1 {
             args = words[1:]
         }
+        if rawArgCommands[cmd] {
+            args = strings.Fields(line)[1:]
+        }
+
+        c.runCommand(cmd, args)
+    }
+}
+
+func (c *CLI) runCommand(cmd string, args []string) {
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    c.mu.Lock()
+    c.cancel = cancel
+    c.mu.Unlock()
+    defer func() {
+        c.mu.Lock()
+        c.cancel = nil
+        c.mu.Unlock()
+    }()
+
+    c.dispatch(ctx, cmd, args)
+}
+
+func (c *CLI) interrupt() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.cancel != nil {
+        c.cancel()
+    }
+}
+
+func (c *CLI) dispatch(ctx context.Context, cmd string, args []string) {
+    switch cmd {
+    case "exit":
+        fmt.Fprintln(c.out, "Closing the Pokedex... Goodbye!")
+        os.Exit(0)
+    case "help":
+        c.cmdHelp()
+    case "map":
+        c.cmdMap(ctx)
+    case "mapb":
+        c.cmdMapBack(ctx)
+    case "explore":
+        c.cmdExplore(ctx, args)
+    case "catch":
+        c.cmdCatch(ctx, args)
+    case "pokedex":
+        c.cmdPokedex()
+    case "inspect":
+        c.cmdInspect(ctx, args)
+    case "battle":
+        c.cmdBattle(ctx, args)
+    case "species":
+        c.cmdSpecies(ctx, args)
+    case "evolutions":
+        c.cmdEvolutions(ctx, args)
+    case "evolve":
+        c.cmdEvolve(ctx, args)
+    case "give":
+        c.cmdGive(args)
+    case "moves":
+        c.cmdMoves(ctx, args)
+    case "move":
+        c.cmdMove(ctx, args)
+    case "nickname":
+        c.cmdNickname(args)
+    case "release":
+        c.cmdRelease(args)
+    case "party":
+        c.cmdParty(args)
+    case "replay":
+        c.cmdReplay(args)
+    case "tournament":
+        c.cmdTournament(ctx, args)
+    case "ladder":
+        c.cmdLadder(args)
+    case "simulate":
+        c.cmdSimulate(ctx, args)
+    default:
+        fmt.Fprintln(c.out, "Unknown command")
+    }
+}
+
+func (c *CLI) onRetry(attempt, maxAttempts int, err error) {
+    fmt.Fprintf(c.out, "retrying (%d/%d)...\n", attempt, maxAttempts)
+}
 
-        switch cmd {
-        case "exit":
-            fmt.Fprintln(c.out, "Closing the Pokedex... Goodbye!")
-            os.Exit(0)
-        case "help":
-            c.cmdHelp()
-        case "map":
-            c.cmdMap()
-        case "mapb":
-            c.cmdMapBack()
-        case "explore":
-            c.cmdExplore(args)
-        case "catch":
-            c.cmdCatch(args)
-        case "pokedex":
-            c.cmdPokedex()
-        case "inspect":
-            c.cmdInspect(args)
-        case "battle":
-            c.cmdBattle(args)
-        default:
-            fmt.Fprintln(c.out, "Unknown command")
+func (c *CLI) printErr(err error) {
+    c.printLookupErr(err, "", "")
+}
+
+func (c *CLI) printLookupErr(err error, kind, name string) {
+    var rateLimited *api.RateLimitError
+    var serverErr *api.ServerError
+    switch {
+    case errors.Is(err, context.Canceled):
+        fmt.Fprintln(c.out, "interrupted")
+    case errors.Is(err, api.ErrNotFound) && kind != "":
+        fmt.Fprintf(c.out, "no %s named %q was found\n", kind, name)
+    case errors.Is(err, api.ErrNotFound):
+        fmt.Fprintln(c.out, "the requested resource was not found")
+    case errors.As(err, &rateLimited):
+        if rateLimited.RetryAfter > 0 {
+            fmt.Fprintf(c.out, "PokeAPI is rate limiting us; try again in %s\n", rateLimited.RetryAfter)
+        } else {
+            fmt.Fprintln(c.out, "PokeAPI is rate limiting us; try again shortly")
         }
+    case errors.As(err, &serverErr):
+        fmt.Fprintf(c.out, "PokeAPI is having trouble (%d %s); try again later\n", serverErr.StatusCode, http.StatusText(serverErr.StatusCode))
+    default:
+        fmt.Fprintln(c.out, err)
     }
 }
 
+func (c *CLI) readLine() (string, bool) {
+    if c.scanner == nil {
+        c.scanner = bufio.NewScanner(c.in)
+    }
+    if !c.scanner.Scan() {
+        return "", false
+    }
+    return c.scanner.Text(), true
+}
+
 func cleanInput(text string) []string {
     s := strings.TrimSpace(text)
     if s == "" {
@@ -106,19 +309,35 @@ func (c *CLI) cmdHelp() {
     fmt.Fprintln(c.out, "  mapb                  - Show previous page of location areas")
     fmt.Fprintln(c.out, "  explore <area>        - Explore a location area and list encountered Pokémon")
     fmt.Fprintln(c.out, "  catch <pokemon> [ball]- Catch a Pokémon; optional ball types: pokeball, greatball, ultraball, masterball")
-    fmt.Fprintln(c.out, "  pokedex               - List caught Pokémon")
-    fmt.Fprintln(c.out, "  inspect <pokemon>     - Show details for a caught Pokémon")
-    fmt.Fprintln(c.out, "  battle <p1> <p2>      - Simulate a simple battle between two caught Pokémon")
+    fmt.Fprintln(c.out, "  pokedex               - List caught Pokémon by species with their IDs")
+    fmt.Fprintln(c.out, "  inspect <pokemon>     - Show details for a caught Pokémon, by species, nickname or ID")
+    fmt.Fprintln(c.out, "  nickname <pokemon> [name] - Name a caught Pokémon, or clear its nickname")
+    fmt.Fprintln(c.out, "  release <pokemon>     - Release a caught Pokémon")
+    fmt.Fprintln(c.out, "  battle [-i] <p1> <p2> - Battle two caught Pokémon using up to four of their moves;")
+    fmt.Fprintln(c.out, "                          list several as a,b,c for a party; -i picks your moves each turn;")
+    fmt.Fprintln(c.out, "                          --ai random|greedy|typeaware|minimax picks the opponent's strategy")
+    fmt.Fprintln(c.out, "  battle team <a> <b>   - Battle two saved parties with switching; ends with a KO and damage summary")
+    fmt.Fprintln(c.out, "  species <pokemon>     - Show species data such as capture rate and Pokédex entry")
+    fmt.Fprintln(c.out, "  evolutions <pokemon>  - Show the evolution chain of a Pokémon and how each stage evolves")
+    fmt.Fprintln(c.out, "  evolve <pokemon> [item] - Evolve a caught Pokémon, optionally using an item such as thunder-stone")
+    fmt.Fprintln(c.out, "  give <pokemon> <item> - Give a caught Pokémon an item to hold")
+    fmt.Fprintln(c.out, "  moves <pokemon> [version-group] - List the moves a Pokémon can learn")
+    fmt.Fprintln(c.out, "  move <name>           - Show power, accuracy, PP and effect of a move")
+    fmt.Fprintln(c.out, "  party [create|add|remove|delete] - Manage parties of up to six caught Pokémon")
+    fmt.Fprintln(c.out, "  replay <file>         - Re-run a recorded battle and check it plays out identically")
+    fmt.Fprintln(c.out, "  tournament [elimination|roundrobin] [--best-of n] [--type t] [pokemon...] - Rank caught Pokémon")
+    fmt.Fprintln(c.out, "  ladder [pokemon]      - Show battle ratings, or one Pokémon's rating history")
+    fmt.Fprintln(c.out, "  simulate <p1> <p2> [--runs n] - Estimate win chances by running many silent battles")
 }
 
-func (c *CLI) cmdMap() {
-    url := api.LocationAreaBase
+func (c *CLI) cmdMap(ctx context.Context) {
+    url := c.api.LocationAreasURL()
     if c.pager.Next != nil && *c.pager.Next != "" {
         url = *c.pager.Next
     }
-    list, err := api.FetchLocationAreas(url)
+    list, err := c.api.FetchLocationAreas(ctx, url)
     if err != nil {
-        fmt.Fprintln(c.out, err)
+        c.printErr(err)
         return
     }
     for _, r := range list.Results {
@@ -128,14 +347,14 @@ func (c *CLI) cmdMap() {
     c.pager.Previous = list.Previous
 }
 
-func (c *CLI) cmdMapBack() {
+func (c *CLI) cmdMapBack(ctx context.Context) {
     if c.pager.Previous == nil || *c.pager.Previous == "" {
         fmt.Fprintln(c.out, "you're on the first page")
         return
     }
-    list, err := api.FetchLocationAreas(*c.pager.Previous)
+    list, err := c.api.FetchLocationAreas(ctx, *c.pager.Previous)
     if err != nil {
-        fmt.Fprintln(c.out, err)
+        c.printErr(err)
         return
     }
     for _, r := range list.Results {
@@ -145,26 +364,26 @@ func (c *CLI) cmdMapBack() {
     c.pager.Previous = list.Previous
 }
 
-func (c *CLI) cmdExplore(args []string) {
+func (c *CLI) cmdExplore(ctx context.Context, args []string) {
     if len(args)==0 {
         fmt.Fprintln(c.out, "usage: explore <area>")
         return
     }
     name := args[0]
     fmt.Fprintf(c.out, "Exploring %s...\n", name)
-    url := api.LocationAreaBase + name + "/"
-    detail, err := api.FetchLocationAreaDetail(url)
+    detail, err := c.api.FetchLocationAreaDetail(ctx, c.api.LocationAreaURL(name))
     if err != nil {
-        fmt.Fprintln(c.out, err)
+        c.printLookupErr(err, "location area", name)
         return
     }
+    c.location = name
     fmt.Fprintln(c.out, "Found Pokemon:")
     for _, pe := range detail.PokemonEncounters {
         fmt.Fprintf(c.out, " - %s\n", pe.Pokemon.Name)
     }
 }
 
-func (c *CLI) cmdCatch(args []string) {
+func (c *CLI) cmdCatch(ctx context.Context, args []string) {
     if len(args)==0 {
         fmt.Fprintln(c.out, "usage: catch <pokemon> [ball]")
         return
@@ -174,139 +393,175 @@ func (c *CLI) cmdCatch(args []string) {
     if len(args)>1 { ball = strings.ToLower(args[1]) }
     if _, ok := ballModifiers[ball]; !ok { fmt.Fprintf(c.out, "unknown ball '%s', using pokeball\n", ball); ball = "pokeball" }
     fmt.Fprintf(c.out, "Throwing a %s at %s...\n", ball, name)
-    p, err := api.FetchPokemon(name)
-    if err != nil { fmt.Fprintln(c.out, err); return }
-    baseChance := 0.5 - float64(p.BaseExperience)/500.0
-    if baseChance < 0.01 { baseChance = 0.01 }
-    if baseChance > 0.99 { baseChance = 0.99 }
+    p, err := c.api.FetchPokemon(ctx, name)
+    if err != nil { c.printLookupErr(err, "pokemon", name); return }
+    baseChance, species, err := c.baseCatchChance(ctx, *p)
+    if err != nil { c.printErr(err); return }
     chance := baseChance * ballModifiers[ball]
     if chance > 0.9999 { chance = 0.9999 }
     if c.randFloat() < chance {
         fmt.Fprintf(c.out, "%s was caught!\n", p.Name)
-        c.store.Add(*p)
-        fmt.Fprintln(c.out, "You may now inspect it with the inspect command.")
+        e := store.NewEntry(*p)
+        if c.rng.Float64() < c.shinyChance {
+            e.Shiny = true
+            fmt.Fprintf(c.out, "%s It's a shiny %s! %s\n", shinyMarker, p.Name, shinyMarker)
+        }
+        if species != nil { e.Happiness = species.BaseHappiness; e.GrowthRate = species.GrowthRate }
+        e.IVs = store.RollIVs(c.rng)
+        e.Nature = c.rollNature(ctx, p.Name)
+        e.CaughtAt = c.clock()
+        e.Location = c.location
+        e.Ball = ball
+        e.History = append(e.History, store.Event{At: e.CaughtAt, Description: "caught in a " + ball})
+        e = c.store.Put(e)
+        fmt.Fprintf(c.out, "Its ID is %s. You may now inspect it with the inspect command.\n", e.ShortID())
     } else {
         fmt.Fprintf(c.out, "%s escaped!\n", p.Name)
     }
 }
 
-func (c *CLI) randFloat() float64 {
-    if c.rng == nil {
-        return rand.Float64()
+func (c *CLI) baseCatchChance(ctx context.Context, p api.Pokemon) (float64, *api.PokemonSpecies, error) {
+    s, err := c.api.FetchPokemonSpecies(ctx, p.SpeciesName())
+    if err == nil {
+        return float64(s.CaptureRate) / 255.0, s, nil
     }
-    return c.rng.Float64()
+    if ctx.Err() != nil {
+        return 0, nil, ctx.Err()
+    }
+    baseChance := 0.5 - float64(p.BaseExperience)/500.0
+    if baseChance < 0.01 { baseChance = 0.01 }
+    if baseChance > 0.99 { baseChance = 0.99 }
+    return baseChance, nil, nil
 }
 
-func (c *CLI) cmdBattle(args []string) {
-    if len(args) < 2 {
-        fmt.Fprintln(c.out, "usage: battle <pokemon1> <pokemon2>")
-        return
+// rollNature picks a random nature from PokeAPI. When natures cannot be
+// looked up the Pokémon gets a neutral one.
+func (c *CLI) rollNature(ctx context.Context, name string) api.Nature {
+    names, err := c.api.FetchNatureNames(ctx)
+    if err == nil && len(names) == 0 {
+        err = errors.New("no natures listed")
     }
-    aName := args[0]
-    bName := args[1]
-
-    a, ok := c.store.Get(aName)
-    if !ok {
-        fmt.Fprintf(c.out, "you have not caught %s\n", aName)
-        return
+    var n *api.Nature
+    if err == nil {
+        n, err = c.api.FetchNature(ctx, names[c.rng.Intn(len(names))])
     }
-    b, ok := c.store.Get(bName)
-    if !ok {
-        fmt.Fprintf(c.out, "you have not caught %s\n", bName)
-        return
+    if err != nil {
+        if ctx.Err() == nil {
+            fmt.Fprintf(c.out, "nature data unavailable (%v); %s has a neutral nature\n", err, name)
+        }
+        return api.Nature{}
     }
+    return *n
+}
 
-    aHP := a.Stats["hp"]
-    bHP := b.Stats["hp"]
-    if aHP <= 0 {
-        aHP = 10
-    }
-    if bHP <= 0 {
-        bHP = 10
+func (c *CLI) clock() time.Time {
+    if c.now == nil {
+        return time.Now()
     }
+    return c.now()
+}
 
-    aAtk := a.Stats["attack"]
-    bAtk := b.Stats["attack"]
-    aDef := a.Stats["defense"]
-    bDef := b.Stats["defense"]
-    aSpd := a.Stats["speed"]
-    bSpd := b.Stats["speed"]
-
-    fmt.Fprintf(c.out, "Battle: %s vs %s\n", aName, bName)
-
-    attackerIsA := true
-    if bSpd > aSpd {
-        attackerIsA = false
+func (c *CLI) randFloat() float64 {
+    if c.rng == nil {
+        return rand.Float64()
     }
+    return c.rng.Float64()
+}
 
-    round := 1
-    for aHP > 0 && bHP > 0 {
-        if attackerIsA {
-            dmg := c.calcDamage(aAtk, bDef)
-            bHP -= dmg
-            if bHP < 0 { bHP = 0 }
-            fmt.Fprintf(c.out, "%s hits %s for %d damage (%d HP left)\n", aName, bName, dmg, bHP)
+func (c *CLI) cmdPokedex() {
+    fmt.Fprintln(c.out, "Your Pokedex:")
+    entries := c.store.List()
+    if len(entries)==0 { fmt.Fprintln(c.out, " (empty)") }
+    species := 0
+    for i := 0; i < len(entries); {
+        j := i
+        for j < len(entries) && entries[j].Name == entries[i].Name { j++ }
+        if j-i == 1 {
+            fmt.Fprintf(c.out, " - %s\n", entries[i].Name)
         } else {
-            dmg := c.calcDamage(bAtk, aDef)
-            aHP -= dmg
-            if aHP < 0 { aHP = 0 }
-            fmt.Fprintf(c.out, "%s hits %s for %d damage (%d HP left)\n", bName, aName, dmg, aHP)
+            fmt.Fprintf(c.out, " - %s x%d\n", entries[i].Name, j-i)
         }
-        attackerIsA = !attackerIsA
-        round++
-        if round > 200 {
-            fmt.Fprintln(c.out, "battle ended in a draw")
-            return
+        for _, e := range entries[i:j] {
+            line := fmt.Sprintf("     %s  Lv %-3d", e.ShortID(), e.Level)
+            if e.Shiny { line += " " + shinyMarker }
+            if e.Nickname != "" { line += " " + e.Nickname }
+            fmt.Fprintln(c.out, line)
         }
+        species++
+        i = j
     }
-
-    if aHP <= 0 && bHP <= 0 {
-        fmt.Fprintln(c.out, "It's a draw!")
-    } else if bHP <= 0 {
-        fmt.Fprintf(c.out, "%s wins!\n", aName)
-    } else {
-        fmt.Fprintf(c.out, "%s wins!\n", bName)
+    if st := c.store.Stats(); st.Caught > 0 {
+        fmt.Fprintf(c.out, "Statistics: %d owned across %d species, %d caught in total, %d shiny\n", len(entries), species, st.Caught, st.Shiny)
     }
 }
 
-func (c *CLI) calcDamage(atk, def int) int {
-    if atk <= 0 { atk = 5 }
-    if def < 0 { def = 0 }
-    base := atk - def/2
-    if base < 1 { base = 1 }
-    factor := 0.85 + c.randFloat()*0.3
-    dmg := int(float64(base) * factor)
-    if dmg < 1 { dmg = 1 }
-    return dmg
-}
-
-func (c *CLI) cmdPokedex() {
-    fmt.Fprintln(c.out, "Your Pokedex:")
-    names := c.store.ListNames()
-    if len(names)==0 { fmt.Fprintln(c.out, " (empty)") ; return }
-    sort.Strings(names)
-    for _, n := range names { fmt.Fprintf(c.out, " - %s\n", n) }
-}
-
-func (c *CLI) cmdInspect(args []string) {
+func (c *CLI) cmdInspect(ctx context.Context, args []string) {
     if len(args)==0 { fmt.Fprintln(c.out, "usage: inspect <pokemon>"); return }
-    name := args[0]
-    p, ok := c.store.Get(name)
-    if !ok { fmt.Fprintln(c.out, "you have not caught that pokemon"); return }
+    p, ok := c.owned(args[0])
+    if !ok { return }
     if p.Height == 0 && p.Weight == 0 && len(p.Stats) == 0 {
-        if fresh, err := api.FetchPokemon(name); err == nil {
-            c.store.Add(*fresh)
-            p = *fresh
+        fresh, err := c.api.FetchPokemon(ctx, p.Name)
+        if err != nil {
+            fmt.Fprint(c.out, "could not refresh details: ")
+            c.printLookupErr(err, "pokemon", p.Name)
+        } else {
+            p, _ = c.store.Update(p.ID, func(e *store.Entry) { e.Pokemon = *fresh })
         }
     }
-    fmt.Fprintf(c.out, "Name: %s\n", p.Name)
+    if p.Shiny {
+        fmt.Fprintf(c.out, "Name: %s %s shiny\n", p.Name, shinyMarker)
+    } else {
+        fmt.Fprintf(c.out, "Name: %s\n", p.Name)
+    }
+    if p.Nickname != "" { fmt.Fprintf(c.out, "Nickname: %s\n", p.Nickname) }
+    fmt.Fprintf(c.out, "ID: %s\n", p.ID)
+    if !p.CaughtAt.IsZero() {
+        caught := "Caught: " + p.CaughtAt.Format("2006-01-02 15:04")
+        if p.Location != "" { caught += " in " + p.Location }
+        if p.Ball != "" { caught += " with a " + p.Ball }
+        fmt.Fprintln(c.out, caught)
+    }
     fmt.Fprintf(c.out, "Height: %d\n", p.Height)
     fmt.Fprintf(c.out, "Weight: %d\n", p.Weight)
+    fmt.Fprintf(c.out, "Level: %d\n", p.Level)
+    if rate, err := c.growthRate(ctx, p); err == nil {
+        xp := p.Experience
+        if min := rate.Experience(p.Level); xp < min { xp = min }
+        if p.Level < store.MaxLevel {
+            fmt.Fprintf(c.out, "Experience: %d (%d to level %d)\n", xp, rate.Experience(p.Level+1)-xp, p.Level+1)
+        } else {
+            fmt.Fprintf(c.out, "Experience: %d\n", xp)
+        }
+    }
+    fmt.Fprintf(c.out, "Happiness: %d\n", p.Happiness)
+    if p.HeldItem != "" { fmt.Fprintf(c.out, "Held item: %s\n", p.HeldItem) }
+    if p.Nature.Name != "" {
+        nature := p.Nature.Name
+        if p.Nature.IncreasedStat != "" { nature += fmt.Sprintf(" (+%s, -%s)", p.Nature.IncreasedStat, p.Nature.DecreasedStat) }
+        fmt.Fprintf(c.out, "Nature: %s\n", nature)
+    }
     fmt.Fprintln(c.out, "Stats:")
-    order := []string{"hp","attack","defense","special-attack","special-defense","speed"}
-    for _, k := range order { if v, ok := p.Stats[k]; ok { fmt.Fprintf(c.out, "  -%s: %d\n", k, v) } }
-    for k, v := range p.Stats { found:=false; for _, okk := range order { if k==okk { found=true; break } }; if !found { fmt.Fprintf(c.out, "  -%s: %d\n", k, v) } }
+    final := p.FinalStats()
+    order := append([]string(nil), store.StatNames...)
+    var extra []string
+    for k := range p.Stats { found:=false; for _, okk := range store.StatNames { if k==okk { found=true; break } }; if !found { extra = append(extra, k) } }
+    sort.Strings(extra)
+    for _, k := range append(order, extra...) {
+        if base, ok := p.Stats[k]; ok {
+            fmt.Fprintf(c.out, "  -%s: %d (base %d, IV %d, EV %d)\n", k, final[k], base, p.IVs[k], p.EVs[k])
+        }
+    }
     fmt.Fprintln(c.out, "Types:")
     for _, t := range p.Types { fmt.Fprintf(c.out, "  - %s\n", t) }
+    if s, err := c.api.FetchPokemonSpecies(ctx, p.SpeciesName()); err == nil {
+        if text := s.FlavorText("en"); text != "" {
+            fmt.Fprintln(c.out, "Pokédex entry:")
+            fmt.Fprintf(c.out, "  %s\n", text)
+        }
+    }
+    if len(p.History) > 0 {
+        fmt.Fprintln(c.out, "History:")
+        for _, ev := range p.History { fmt.Fprintf(c.out, "  - %s: %s\n", ev.At.Format("2006-01-02 15:04"), ev.Description) }
+    }
 }
 
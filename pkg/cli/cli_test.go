@@ -1,6 +1,21 @@
 package cli
 
-import "testing"
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "math/rand"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
 
 func TestCleanInput(t *testing.T) {
     cases := []struct {
@@ -27,3 +42,199 @@ func TestCleanInput(t *testing.T) {
         }
     }
 }
+
+func TestInterruptCancelsInFlightCommand(t *testing.T) {
+    out := &bytes.Buffer{}
+    c := &CLI{out: out, store: store.NewStore()}
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        c.interrupt()
+        <-r.Context().Done()
+    }))
+    defer ts.Close()
+    c.api = api.NewClient(api.WithBaseURL(ts.URL))
+
+    c.runCommand("explore", []string{"slow-area"})
+
+    if !strings.Contains(out.String(), "interrupted") {
+        t.Fatalf("expected command to be interrupted; output:\n%s", out.String())
+    }
+    if c.cancel != nil {
+        t.Fatalf("expected cancel func to be cleared after the command returned")
+    }
+}
+
+func TestFriendlyAPIErrors(t *testing.T) {
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/pokemon/pikchu/":
+            http.Error(w, "Not Found", http.StatusNotFound)
+        case "/location-area/busy-area/":
+            w.Header().Set("Retry-After", "30")
+            w.WriteHeader(http.StatusTooManyRequests)
+        default:
+            w.WriteHeader(http.StatusServiceUnavailable)
+        }
+    }))
+    defer ts.Close()
+
+    cases := []struct {
+        cmd      string
+        args     []string
+        expected string
+    }{
+        {cmd: "catch", args: []string{"pikchu"}, expected: `no pokemon named "pikchu" was found`},
+        {cmd: "explore", args: []string{"busy-area"}, expected: "try again in 30s"},
+        {cmd: "catch", args: []string{"pikachu"}, expected: "PokeAPI is having trouble (503 Service Unavailable)"},
+    }
+    for _, tc := range cases {
+        out := &bytes.Buffer{}
+        c := &CLI{out: out, store: store.NewStore(), api: api.NewClient(api.WithBaseURL(ts.URL))}
+        c.runCommand(tc.cmd, tc.args)
+        if !strings.Contains(out.String(), tc.expected) {
+            t.Errorf("%s %v: expected %q in output:\n%s", tc.cmd, tc.args, tc.expected, out.String())
+        }
+    }
+}
+
+func TestRetryProgressIsPrinted(t *testing.T) {
+    calls := 0
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        calls++
+        if calls == 1 {
+            w.WriteHeader(http.StatusBadGateway)
+            return
+        }
+        w.Write([]byte(`{"name": "area-1", "pokemon_encounters": [{"pokemon": {"name": "p1"}}]}`))
+    }))
+    defer ts.Close()
+
+    out := &bytes.Buffer{}
+    c := &CLI{out: out, store: store.NewStore()}
+    policy := api.DefaultRetryPolicy()
+    policy.BaseDelay = time.Millisecond
+    c.api = api.NewClient(api.WithBaseURL(ts.URL), api.WithRetryPolicy(policy), api.WithRetryHook(c.onRetry))
+
+    c.runCommand("explore", []string{"area-1"})
+    got := out.String()
+    if !strings.Contains(got, "retrying (2/4)...") || !strings.Contains(got, " - p1") {
+        t.Fatalf("expected retry notice followed by results; output:\n%s", got)
+    }
+}
+
+func newTestCLI(t *testing.T, routes map[string]string) (*CLI, *bytes.Buffer) {
+    t.Helper()
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, ok := routes[r.URL.Path]
+        if !ok {
+            http.NotFound(w, r)
+            return
+        }
+        w.Write([]byte(body))
+    }))
+    t.Cleanup(ts.Close)
+
+    out := &bytes.Buffer{}
+    c := &CLI{out: out, store: store.NewStore(), api: api.NewClient(api.WithBaseURL(ts.URL)), rng: rand.New(rand.NewSource(1))}
+    return c, out
+}
+
+func TestRunLoadsSaveFile(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "save.json")
+    s, err := store.Open(path)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    s.Add(api.Pokemon{Name: "pikachu"})
+    s.SetParty("red", []string{"pikachu"})
+
+    out := &bytes.Buffer{}
+    if err := Run(strings.NewReader("pokedex\nparty\n"), out, WithSavePath(path)); err != nil {
+        t.Fatalf("Run error: %v", err)
+    }
+    if got := out.String(); !strings.Contains(got, " - pikachu") || !strings.Contains(got, " - red: pikachu") {
+        t.Fatalf("expected the saved pokedex to be loaded:\n%s", got)
+    }
+}
+
+func TestRunRefusesCorruptSaveFile(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "save.json")
+    os.WriteFile(path, []byte("{oops"), 0o644)
+
+    out := &bytes.Buffer{}
+    err := Run(strings.NewReader("pokedex\n"), out, WithSavePath(path))
+    if !errors.Is(err, store.ErrCorrupt) {
+        t.Fatalf("expected a corrupt save error, got %v", err)
+    }
+    if strings.Contains(out.String(), "Pokedex >") {
+        t.Fatalf("expected the REPL not to start with a corrupt save:\n%s", out.String())
+    }
+    if data, _ := os.ReadFile(path); string(data) != "{oops" {
+        t.Fatalf("expected the corrupt file to be left alone, got %q", data)
+    }
+}
+
+func TestCatchRollsIVsAndNature(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon/pikachu/":         testPikachuJSON,
+        "/pokemon-species/pikachu/": testPikachuSpeciesJSON,
+        "/nature/":                  `{"results": [{"name": "adamant"}]}`,
+        "/nature/adamant/":          `{"name": "adamant", "increased_stat": {"name": "attack"}, "decreased_stat": {"name": "special-attack"}}`,
+    })
+    for len(c.store.List()) == 0 {
+        c.runCommand("catch", []string{"pikachu", "masterball"})
+    }
+    e := c.store.List()[0]
+    if len(e.IVs) != len(store.StatNames) || e.Nature.Name != "adamant" {
+        t.Fatalf("expected IVs and a nature on the catch, got %+v", e)
+    }
+
+    out.Reset()
+    c.runCommand("inspect", []string{"pikachu"})
+    got := out.String()
+    final := e.FinalStats()
+    for _, want := range []string{
+        "Nature: adamant (+attack, -special-attack)",
+        fmt.Sprintf("  -hp: %d (base 35, IV %d, EV 0)", final["hp"], e.IVs["hp"]),
+        fmt.Sprintf("  -attack: %d (base 55, IV %d, EV 0)", final["attack"], e.IVs["attack"]),
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+}
+
+func TestShinyCatchesAreMarkedAndCounted(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon/pikachu/":         testPikachuJSON,
+        "/pokemon-species/pikachu/": testPikachuSpeciesJSON,
+    })
+    c.shinyChance = 1
+    for len(c.store.List()) == 0 {
+        c.runCommand("catch", []string{"pikachu", "masterball"})
+    }
+    c.shinyChance = 0
+    for len(c.store.List()) == 1 {
+        c.runCommand("catch", []string{"pikachu", "masterball"})
+    }
+    shiny := c.store.List()[0]
+    if !shiny.Shiny || c.store.List()[1].Shiny {
+        t.Fatalf("expected only the first catch to be shiny: %+v", c.store.List())
+    }
+
+    c.runCommand("pokedex", nil)
+    c.runCommand("inspect", []string{shiny.ShortID()})
+    got := out.String()
+    for _, want := range []string{
+        "★ It's a shiny pikachu! ★",
+        shiny.ShortID() + "  Lv 5   ★",
+        "Statistics: 2 owned across 1 species, 2 caught in total, 1 shiny",
+        "Name: pikachu ★ shiny",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+    if strings.Count(got, "It's a shiny") != 1 {
+        t.Fatalf("expected a single shiny message:\n%s", got)
+    }
+}
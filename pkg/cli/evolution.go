@@ -0,0 +1,189 @@
+package cli
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "strings"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
+
+func (c *CLI) cmdEvolutions(ctx context.Context, args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: evolutions <pokemon>")
+        return
+    }
+    name := args[0]
+    s, err := c.lookupSpecies(ctx, name)
+    if err != nil {
+        c.printLookupErr(err, "pokemon", name)
+        return
+    }
+    if s.EvolutionChainURL == "" {
+        fmt.Fprintf(c.out, "%s does not evolve\n", s.Name)
+        return
+    }
+    chain, err := c.api.FetchEvolutionChain(ctx, s.EvolutionChainURL)
+    if err != nil {
+        c.printErr(err)
+        return
+    }
+    fmt.Fprintf(c.out, "Evolution chain for %s:\n", s.Name)
+    renderEvolutionTree(c.out, chain.Chain)
+}
+
+func renderEvolutionTree(w io.Writer, root api.ChainLink) {
+    fmt.Fprintln(w, root.Species)
+    renderEvolutionChildren(w, root, "")
+}
+
+func renderEvolutionChildren(w io.Writer, link api.ChainLink, prefix string) {
+    for i, next := range link.EvolvesTo {
+        branch, indent := "+-- ", "|   "
+        if i == len(link.EvolvesTo)-1 {
+            branch, indent = "`-- ", "    "
+        }
+        if cond := next.Conditions(); cond != "" {
+            fmt.Fprintf(w, "%s%s%s (%s)\n", prefix, branch, next.Species, cond)
+        } else {
+            fmt.Fprintf(w, "%s%s%s\n", prefix, branch, next.Species)
+        }
+        renderEvolutionChildren(w, next, prefix+indent)
+    }
+}
+
+func (c *CLI) cmdGive(args []string) {
+    if len(args) < 2 {
+        fmt.Fprintln(c.out, "usage: give <pokemon> <item>")
+        return
+    }
+    name, item := args[0], args[1]
+    e, ok := c.owned(name)
+    if !ok {
+        return
+    }
+    c.store.Update(e.ID, func(e *store.Entry) { e.HeldItem = item })
+    fmt.Fprintf(c.out, "%s is now holding %s\n", name, item)
+}
+
+func (c *CLI) cmdEvolve(ctx context.Context, args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: evolve <pokemon> [item]")
+        return
+    }
+    name := args[0]
+    item := ""
+    if len(args) > 1 {
+        item = args[1]
+    }
+    e, ok := c.owned(name)
+    if !ok {
+        return
+    }
+
+    s, err := c.api.FetchPokemonSpecies(ctx, e.SpeciesName())
+    if err != nil {
+        c.printLookupErr(err, "species", e.SpeciesName())
+        return
+    }
+    if s.EvolutionChainURL == "" {
+        fmt.Fprintf(c.out, "%s does not evolve\n", name)
+        return
+    }
+    chain, err := c.api.FetchEvolutionChain(ctx, s.EvolutionChainURL)
+    if err != nil {
+        c.printErr(err)
+        return
+    }
+    link := chain.Chain.Find(s.Name)
+    if link == nil || len(link.EvolvesTo) == 0 {
+        fmt.Fprintf(c.out, "%s does not evolve any further\n", name)
+        return
+    }
+
+    var blockers []string
+    for _, next := range link.EvolvesTo {
+        for _, d := range next.EvolutionDetails {
+            if reason := c.evolutionBlocker(e, d, item); reason != "" {
+                blockers = append(blockers, fmt.Sprintf("%s: %s", next.Species, reason))
+                continue
+            }
+            c.evolveInto(ctx, e, next.Species, d)
+            return
+        }
+    }
+    fmt.Fprintf(c.out, "%s is not ready to evolve:\n", name)
+    for _, b := range blockers {
+        fmt.Fprintf(c.out, "  - %s\n", b)
+    }
+}
+
+func (c *CLI) evolveInto(ctx context.Context, e store.Entry, species string, d api.EvolutionDetail) {
+    p, err := c.api.FetchPokemon(ctx, species)
+    if err != nil {
+        c.printLookupErr(err, "pokemon", species)
+        return
+    }
+    evolved, err := c.store.Evolve(e.ID, *p, c.clock())
+    if err != nil {
+        fmt.Fprintln(c.out, err)
+        return
+    }
+    if d.HeldItem != "" {
+        c.store.Update(evolved.ID, func(e *store.Entry) { e.HeldItem = "" })
+    }
+    if c.ladder != nil {
+        c.ladder.Rename(e.ID, evolved.DisplayName())
+        c.saveLadder()
+    }
+    fmt.Fprintf(c.out, "What? %s is evolving!\n", e.Name)
+    fmt.Fprintf(c.out, "Congratulations! Your %s evolved into %s!\n", e.Name, evolved.Name)
+}
+
+// evolutionBlocker explains why d is not yet satisfied by e, or returns ""
+// when the Pokémon can evolve this way right now.
+func (c *CLI) evolutionBlocker(e store.Entry, d api.EvolutionDetail, item string) string {
+    switch d.Trigger {
+    case "level-up":
+    case "use-item":
+        if item != d.Item {
+            return fmt.Sprintf("needs a %s (evolve %s %s)", d.Item, e.Name, d.Item)
+        }
+    case "trade":
+        return "requires a trade"
+    default:
+        return fmt.Sprintf("%s evolutions are not supported", d.Trigger)
+    }
+    if extra := d.ExtraConditions(); len(extra) > 0 {
+        return fmt.Sprintf("needs %s, not supported", strings.Join(extra, ", "))
+    }
+    if d.MinLevel > 0 && e.Level < d.MinLevel {
+        return fmt.Sprintf("needs level %d (currently %d)", d.MinLevel, e.Level)
+    }
+    if d.HeldItem != "" && e.HeldItem != d.HeldItem {
+        return fmt.Sprintf("needs to hold %s", d.HeldItem)
+    }
+    if d.MinHappiness > 0 && e.Happiness < d.MinHappiness {
+        return fmt.Sprintf("needs happiness %d (currently %d)", d.MinHappiness, e.Happiness)
+    }
+    if d.TimeOfDay != "" && timeOfDay(c.clock()) != d.TimeOfDay {
+        return fmt.Sprintf("only evolves %s", api.EvolutionDetail{TimeOfDay: d.TimeOfDay}.String())
+    }
+    return ""
+}
+
+func timeOfDay(t time.Time) string {
+    switch h := t.Hour(); {
+    case h < 6:
+        return "night"
+    case h < 17:
+        return "day"
+    case h < 19:
+        return "dusk"
+    default:
+        return "night"
+    }
+}
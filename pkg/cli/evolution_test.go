@@ -0,0 +1,186 @@
+package cli
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
+
+const testOddishSpeciesJSON = `{
+  "name": "oddish",
+  "capture_rate": 255,
+  "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/18/"},
+  "growth_rate": {"name": "medium-slow"},
+  "color": {"name": "blue"}
+}`
+
+const testOddishChainJSON = `{
+  "id": 18,
+  "chain": {
+    "species": {"name": "oddish"},
+    "evolution_details": [],
+    "evolves_to": [{
+      "species": {"name": "gloom"},
+      "evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 21}],
+      "evolves_to": [
+        {"species": {"name": "vileplume"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}], "evolves_to": []},
+        {"species": {"name": "bellossom"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "sun-stone"}}], "evolves_to": []}
+      ]
+    }]
+  }
+}`
+
+func TestEvolutionsCommandRendersTree(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon-species/oddish/": testOddishSpeciesJSON,
+        "/evolution-chain/18/":     testOddishChainJSON,
+    })
+
+    c.runCommand("evolutions", []string{"oddish"})
+
+    expected := "Evolution chain for oddish:\n" +
+        "oddish\n" +
+        "`-- gloom (level 21)\n" +
+        "    +-- vileplume (use leaf-stone)\n" +
+        "    `-- bellossom (use sun-stone)\n"
+    if out.String() != expected {
+        t.Fatalf("unexpected tree:\n%s\nexpected:\n%s", out.String(), expected)
+    }
+}
+
+const testPikachuChainJSON = `{
+  "id": 10,
+  "chain": {
+    "species": {"name": "pichu"},
+    "evolution_details": [],
+    "evolves_to": [{
+      "species": {"name": "pikachu"},
+      "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 220}],
+      "evolves_to": [{
+        "species": {"name": "raichu"},
+        "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}],
+        "evolves_to": []
+      }]
+    }]
+  }
+}`
+
+func TestEvolveChecksTriggersAndReplacesEntry(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon-species/pichu/":   `{"name": "pichu", "base_happiness": 50, "evolution_chain": {"url": "/evolution-chain/10/"}}`,
+        "/pokemon-species/pikachu/": testPikachuSpeciesJSON,
+        "/evolution-chain/10/":      testPikachuChainJSON,
+        "/pokemon/pikachu/":         testPikachuJSON,
+        "/pokemon/raichu/":          `{"name": "raichu", "species": {"name": "raichu"}, "height": 8, "weight": 300, "types": [{"type": {"name": "electric"}}]}`,
+    })
+    pichu := store.NewEntry(api.Pokemon{Name: "pichu", Species: "pichu"})
+    pichu.Happiness = 100
+    c.store.Put(pichu)
+
+    c.runCommand("evolve", []string{"pichu"})
+    if !strings.Contains(out.String(), "pikachu: needs happiness 220 (currently 100)") {
+        t.Fatalf("expected happiness blocker:\n%s", out.String())
+    }
+
+    c.store.Update("pichu", func(e *store.Entry) { e.Happiness = 230 })
+    out.Reset()
+    c.runCommand("evolve", []string{"pichu"})
+    if !strings.Contains(out.String(), "Your pichu evolved into pikachu!") {
+        t.Fatalf("expected pichu to evolve:\n%s", out.String())
+    }
+    pikachu, ok := c.store.Get("pikachu")
+    if !ok || pikachu.Height != 4 || pikachu.Happiness != 230 {
+        t.Fatalf("expected evolved pikachu with refetched stats and kept happiness, got %+v", pikachu)
+    }
+    if len(pikachu.History) != 1 || !strings.Contains(pikachu.History[0].Description, "evolved from pichu into pikachu") {
+        t.Fatalf("expected evolution in history, got %+v", pikachu.History)
+    }
+
+    out.Reset()
+    c.runCommand("evolve", []string{"pikachu"})
+    if !strings.Contains(out.String(), "raichu: needs a thunder-stone") {
+        t.Fatalf("expected item blocker:\n%s", out.String())
+    }
+    out.Reset()
+    c.runCommand("evolve", []string{"pikachu", "thunder-stone"})
+    if _, ok := c.store.Get("raichu"); !ok {
+        t.Fatalf("expected raichu after using thunder-stone:\n%s", out.String())
+    }
+}
+
+func TestEvolutionBlockerTimeOfDay(t *testing.T) {
+    c := &CLI{now: func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) }}
+    e := store.NewEntry(api.Pokemon{Name: "eevee"})
+    e.Happiness = 200
+    d := api.EvolutionDetail{Trigger: "level-up", MinHappiness: 160, TimeOfDay: "night"}
+    if got := c.evolutionBlocker(e, d, ""); got != "only evolves at night" {
+        t.Fatalf("expected night blocker, got %q", got)
+    }
+    d.TimeOfDay = "day"
+    if got := c.evolutionBlocker(e, d, ""); got != "" {
+        t.Fatalf("expected no blocker during the day, got %q", got)
+    }
+}
+
+const testEeveeChainJSON = `{
+  "id": 67,
+  "chain": {
+    "species": {"name": "eevee"},
+    "evolution_details": [],
+    "evolves_to": [
+      {"species": {"name": "vaporeon"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}], "evolves_to": []},
+      {"species": {"name": "espeon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}], "evolves_to": []},
+      {"species": {"name": "leafeon"}, "evolution_details": [
+        {"trigger": {"name": "level-up"}, "location": {"name": "eterna-forest"}, "min_level": null, "gender": null, "known_move": null, "relative_physical_stats": null},
+        {"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}
+      ], "evolves_to": []},
+      {"species": {"name": "sylveon"}, "evolution_details": [{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}, "min_affection": 2}], "evolves_to": []}
+    ]
+  }
+}`
+
+func TestEvolveRefusesUnsupportedConditions(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon-species/eevee/": `{"name": "eevee", "evolution_chain": {"url": "/evolution-chain/67/"}}`,
+        "/evolution-chain/67/":    testEeveeChainJSON,
+        "/pokemon/leafeon/":       `{"name": "leafeon", "species": {"name": "leafeon"}, "types": [{"type": {"name": "grass"}}]}`,
+    })
+    c.now = func() time.Time { return time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC) }
+    c.store.Add(api.Pokemon{Name: "eevee", Species: "eevee"})
+
+    c.runCommand("evolve", []string{"eevee"})
+    got := out.String()
+    for _, want := range []string{
+        "eevee is not ready to evolve:",
+        "leafeon: needs location eterna-forest, not supported",
+        "sylveon: needs a fairy move, affection 2+, not supported",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+    if _, ok := c.store.Get("eevee"); !ok {
+        t.Fatalf("expected eevee not to evolve:\n%s", got)
+    }
+
+    out.Reset()
+    c.runCommand("evolve", []string{"eevee", "leaf-stone"})
+    if _, ok := c.store.Get("leafeon"); !ok {
+        t.Fatalf("expected a leaf-stone to still work:\n%s", out.String())
+    }
+}
+
+func TestEvolveWithoutEvolutionChain(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon-species/mew/": `{"name": "mew", "evolution_chain": null}`,
+    })
+    c.store.Add(api.Pokemon{Name: "mew", Species: "mew"})
+
+    c.runCommand("evolve", []string{"mew"})
+    if got := out.String(); got != "mew does not evolve\n" {
+        t.Fatalf("unexpected output %q", got)
+    }
+}
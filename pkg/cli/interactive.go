@@ -0,0 +1,129 @@
+package cli
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/battle"
+)
+
+const hpBarWidth = 20
+
+// promptController lets the player pick each action from the same input
+// the REPL reads commands from.
+type promptController struct {
+    c   *CLI
+    ctx context.Context
+}
+
+func (p *promptController) ChooseAction(b *battle.Battle, side int) battle.Action {
+    c := p.c
+    c.printBattleStatus(b, side)
+    me := b.Active(side)
+    fmt.Fprintln(c.out, "Moves:")
+    for i, m := range me.Moves {
+        fmt.Fprintf(c.out, "  %d) %s (%s, power %s, PP %d/%d)\n", i+1, m.Name, m.Type, orDash(m.Power), me.PP(i), m.PP)
+    }
+    if bench := b.Sides[side].Bench(); len(bench) > 0 {
+        fmt.Fprintln(c.out, "Party:")
+        for _, i := range bench {
+            member := b.Sides[side].Team[i]
+            fmt.Fprintf(c.out, "  switch %d) %s (%d/%d HP)\n", i+1, member.Name, member.HP, member.Stats.HP)
+        }
+    }
+
+    for {
+        if p.ctx.Err() != nil {
+            return battle.Action{Kind: battle.Forfeit}
+        }
+        fmt.Fprint(c.out, "What will you do? > ")
+        line, ok := c.readLine()
+        if !ok {
+            return battle.Action{Kind: battle.Forfeit}
+        }
+        words := cleanInput(line)
+        if len(words) == 0 {
+            continue
+        }
+        switch words[0] {
+        case "forfeit", "run":
+            return battle.Action{Kind: battle.Forfeit}
+        case "switch":
+            if len(words) < 2 {
+                fmt.Fprintln(c.out, "usage: switch <number|name>")
+                continue
+            }
+            i := teamIndex(b.Sides[side], words[1])
+            if !b.Sides[side].CanSwitchTo(i) {
+                fmt.Fprintf(c.out, "can't switch to %s\n", words[1])
+                continue
+            }
+            return battle.SwitchAction(i)
+        }
+        n, err := strconv.Atoi(words[0])
+        if err != nil || n < 1 || n > len(me.Moves) {
+            fmt.Fprintln(c.out, "choose a move number, switch <number|name>, or forfeit")
+            continue
+        }
+        if len(me.UsableMoves()) == 0 {
+            fmt.Fprintf(c.out, "%s has no PP left!\n", me.Name)
+            return battle.MoveAction(-1)
+        }
+        if !me.CanUse(n - 1) {
+            fmt.Fprintf(c.out, "%s has no PP left\n", me.Moves[n-1].Name)
+            continue
+        }
+        return battle.MoveAction(n - 1)
+    }
+}
+
+func (p *promptController) ChooseReplacement(b *battle.Battle, side int) int {
+    c := p.c
+    s := b.Sides[side]
+    bench := s.Bench()
+    fmt.Fprintf(c.out, "%s fainted! Choose a replacement:\n", s.Current().Name)
+    for _, i := range bench {
+        fmt.Fprintf(c.out, "  %d) %s (%d/%d HP)\n", i+1, s.Team[i].Name, s.Team[i].HP, s.Team[i].Stats.HP)
+    }
+    for {
+        fmt.Fprint(c.out, "Send out > ")
+        line, ok := c.readLine()
+        if !ok || p.ctx.Err() != nil {
+            return bench[0]
+        }
+        words := cleanInput(line)
+        if len(words) == 0 {
+            continue
+        }
+        if i := teamIndex(s, words[0]); s.CanSwitchTo(i) {
+            return i
+        }
+        fmt.Fprintf(c.out, "can't send out %s\n", words[0])
+    }
+}
+
+func teamIndex(s *battle.Side, ref string) int {
+    if n, err := strconv.Atoi(ref); err == nil {
+        return n - 1
+    }
+    for i, member := range s.Team {
+        if strings.EqualFold(member.Name, ref) {
+            return i
+        }
+    }
+    return -1
+}
+
+func (c *CLI) printBattleStatus(b *battle.Battle, side int) {
+    fmt.Fprintf(c.out, "--- Turn %d ---\n", b.Turn+1)
+    for _, i := range []int{1 - side, side} {
+        active := b.Active(i)
+        status := ""
+        if label := battle.StatusLabel(active); label != "" {
+            status = " " + label
+        }
+        fmt.Fprintf(c.out, "%-9s %-12s Lv%-3d %s %d/%d HP%s\n", b.Sides[i].Name, active.Name, active.Level, battle.HPBar(active, hpBarWidth), active.HP, active.Stats.HP, status)
+    }
+}
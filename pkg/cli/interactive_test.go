@@ -0,0 +1,75 @@
+package cli
+
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func newInteractiveCLI(t *testing.T, input string) (*CLI, *bytes.Buffer) {
+    c, out := newTestCLI(t, nil)
+    c.in = strings.NewReader(input)
+    c.store.Add(api.Pokemon{Name: "weak", Stats: map[string]int{"hp": 1, "attack": 1, "defense": 1, "speed": 1}})
+    c.store.Add(api.Pokemon{Name: "tank", Stats: map[string]int{"hp": 500, "attack": 200, "defense": 50, "speed": 1}})
+    c.store.Add(api.Pokemon{Name: "foe", Stats: map[string]int{"hp": 60, "attack": 10, "defense": 10, "speed": 50}})
+    return c, out
+}
+
+func TestInteractiveBattleWithScriptedInput(t *testing.T) {
+    c, out := newInteractiveCLI(t, "bogus\nswitch tank\n" + strings.Repeat("1\n", 20))
+
+    c.cmdBattle(context.Background(), []string{"--interactive", "weak,tank", "foe"})
+    got := out.String()
+    for _, want := range []string{
+        "--- Turn 1 ---",
+        "Opponent  foe          Lv5   [####################] 21/21 HP",
+        "Player    weak         Lv5   [####################] 15/15 HP",
+        "  1) tackle (normal, power 40, PP 35/35)",
+        "  switch 2) tank (65/65 HP)",
+        "choose a move number, switch <number|name>, or forfeit",
+        "Player withdrew weak and sent out tank!",
+        "Player wins!",
+    } {
+        if !strings.Contains(got, want) {
+            t.Errorf("expected %q in output:\n%s", want, got)
+        }
+    }
+    if strings.Contains(got, "weak used") {
+        t.Errorf("weak should have been switched out before acting:\n%s", got)
+    }
+}
+
+func TestInteractiveBattleForfeitsOnEOF(t *testing.T) {
+    c, out := newInteractiveCLI(t, "")
+
+    c.cmdBattle(context.Background(), []string{"-i", "tank", "foe"})
+    got := out.String()
+    if !strings.Contains(got, "Player forfeited the battle!") || !strings.Contains(got, "Opponent wins!") {
+        t.Fatalf("expected a forfeit when input runs out:\n%s", got)
+    }
+}
+
+func TestInteractiveReplacementPrompt(t *testing.T) {
+    c, out := newInteractiveCLI(t, "1\n1\n1\n9\ntank\n" + strings.Repeat("1\n", 20))
+
+    c.cmdBattle(context.Background(), []string{"-i", "weak,tank", "foe"})
+    got := out.String()
+    for _, want := range []string{"weak fainted! Choose a replacement:", "can't send out 9", "Player sent out tank!", "Player wins!"} {
+        if !strings.Contains(got, want) {
+            t.Errorf("expected %q in output:\n%s", want, got)
+        }
+    }
+}
+
+func TestInteractiveSwitchByNickname(t *testing.T) {
+    c, out := newInteractiveCLI(t, "switch Sparky\n" + strings.Repeat("1\n", 20))
+    c.runCommand("nickname", []string{"tank", "Sparky"})
+
+    c.cmdBattle(context.Background(), []string{"--interactive", "weak,sparky", "foe"})
+    if got := out.String(); !strings.Contains(got, "Player withdrew weak and sent out Sparky!") {
+        t.Fatalf("expected to switch to the nicknamed bench member:\n%s", got)
+    }
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+    "fmt"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/ladder"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
+
+const ladderHistoryLen = 10
+
+// rate records a battle result on the ladder without saving it.
+func (c *CLI) rate(teams [2][]store.Entry, winner int) {
+    if c.ladder == nil {
+        return
+    }
+    var entrants [2][]ladder.Entrant
+    for i, team := range teams {
+        for _, e := range team {
+            entrants[i] = append(entrants[i], ladder.Entrant{ID: e.ID, Name: e.DisplayName()})
+        }
+    }
+    c.ladder.Record(entrants, winner, c.clock())
+}
+
+// migrateLadder moves ratings saved under names onto the Pokémon those
+// names refer to now.
+func (c *CLI) migrateLadder() {
+    changed := c.ladder.Migrate(func(name string) (ladder.Entrant, bool) {
+        e, err := c.store.Resolve(name)
+        if err != nil {
+            return ladder.Entrant{}, false
+        }
+        return ladder.Entrant{ID: e.ID, Name: e.DisplayName()}, true
+    })
+    if changed {
+        c.saveLadder()
+    }
+}
+
+func (c *CLI) saveLadder() {
+    if c.ladder == nil {
+        return
+    }
+    if err := c.ladder.Save(); err != nil {
+        fmt.Fprintf(c.out, "could not save ladder: %v\n", err)
+    }
+}
+
+func (c *CLI) cmdLadder(args []string) {
+    if c.ladder == nil {
+        fmt.Fprintln(c.out, "the ladder is unavailable this session")
+        return
+    }
+    if len(args) > 0 {
+        c.printLadderHistory(args[0])
+        return
+    }
+    ranks := c.ladder.Rankings()
+    if len(ranks) == 0 {
+        fmt.Fprintln(c.out, "no rated battles yet; battle to get on the ladder")
+        return
+    }
+    fmt.Fprintf(c.out, "%-4s %-12s %-8s %6s %9s\n", "Rank", "Pokemon", "ID", "Rating", "W-L-D")
+    for i, p := range ranks {
+        record := fmt.Sprintf("%d-%d-%d", p.Wins, p.Losses, p.Draws)
+        fmt.Fprintf(c.out, "%-4d %-12s %-8s %6.0f %9s\n", i+1, p.Name, store.Entry{ID: p.ID}.ShortID(), p.Rating, record)
+    }
+}
+
+// printLadderHistory accepts a ladder ID or anything that names an owned
+// Pokémon.
+func (c *CLI) printLadderHistory(name string) {
+    p, ok := c.ladder.Get(name)
+    if e, err := c.store.Resolve(name); !ok && err == nil {
+        p, ok = c.ladder.Get(e.ID)
+    }
+    if !ok {
+        fmt.Fprintf(c.out, "%s has no rated battles\n", name)
+        return
+    }
+    fmt.Fprintf(c.out, "%s: rating %.0f, %d wins, %d losses, %d draws\n", p.Name, p.Rating, p.Wins, p.Losses, p.Draws)
+    history := p.History
+    if len(history) > ladderHistoryLen {
+        history = history[len(history)-ladderHistoryLen:]
+    }
+    for _, h := range history {
+        fmt.Fprintf(c.out, "  %s  %-4s vs %-16s -> %.0f\n", h.At.Format("2006-01-02 15:04"), h.Outcome, h.Opponent, h.Rating)
+    }
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+    "bytes"
+    "context"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/ladder"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
+
+func TestBattlesUpdateLadder(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    path := filepath.Join(t.TempDir(), "ladder.json")
+    c.ladder = ladder.New(path)
+    weak := c.store.Add(api.Pokemon{Name: "weak", Stats: map[string]int{"hp": 10, "attack": 1, "defense": 1, "speed": 10}})
+    strong := c.store.Add(api.Pokemon{Name: "strong", Stats: map[string]int{"hp": 100, "attack": 1000, "defense": 1, "speed": 5}})
+
+    c.cmdLadder(nil)
+    if !strings.Contains(out.String(), "no rated battles yet") {
+        t.Fatalf("expected an empty ladder, got %q", out.String())
+    }
+
+    c.cmdBattle(context.Background(), []string{"weak", "strong"})
+    c.cmdBattle(context.Background(), []string{"strong", "weak"})
+    out.Reset()
+    c.cmdLadder(nil)
+    got := out.String()
+    if !strings.Contains(got, "1    strong       "+strong.ShortID()+"   1531     2-0-0") || !strings.Contains(got, "2    weak         "+weak.ShortID()+"   1469     0-2-0") {
+        t.Fatalf("unexpected ladder:\n%s", got)
+    }
+
+    out.Reset()
+    c.cmdLadder([]string{"weak"})
+    if got := out.String(); !strings.Contains(got, "weak: rating 1469, 0 wins, 2 losses") || strings.Count(got, "loss vs strong") != 2 {
+        t.Fatalf("unexpected history:\n%s", got)
+    }
+
+    reloaded, err := ladder.Load(path)
+    if err != nil {
+        t.Fatalf("Load error: %v", err)
+    }
+    if p, ok := reloaded.Get(strong.ID); !ok || p.Wins != 2 || p.Name != "strong" {
+        t.Fatalf("expected the ladder to be saved after each battle, got %+v", p)
+    }
+}
+
+func TestLadderRatesEachPokemonSeparately(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    c.ladder = ladder.New(filepath.Join(t.TempDir(), "ladder.json"))
+    stats := map[string]int{"hp": 50, "attack": 50, "defense": 50, "speed": 50}
+    first := c.store.Add(api.Pokemon{Name: "pikachu", Stats: stats})
+    second := c.store.Add(api.Pokemon{Name: "pikachu", Stats: stats})
+
+    c.cmdBattle(context.Background(), []string{first.ID, second.ID})
+    a, okA := c.ladder.Get(first.ID)
+    b, okB := c.ladder.Get(second.ID)
+    if !okA || !okB || a.Wins+a.Losses+a.Draws != 1 || b.Wins+b.Losses+b.Draws != 1 {
+        t.Fatalf("expected both pikachu to be rated, got %+v and %+v\n%s", a, b, out.String())
+    }
+
+    c.cmdNickname([]string{first.ID, "Sparky"})
+    c.cmdRelease([]string{second.ID})
+    third := c.store.Add(api.Pokemon{Name: "pikachu", Stats: stats})
+    if p, _ := c.ladder.Get(first.ID); p.Name != "Sparky" {
+        t.Fatalf("expected the ladder label to follow the nickname, got %+v", p)
+    }
+    if _, ok := c.ladder.Get(third.ID); ok {
+        t.Fatalf("expected a new catch to start without a rating")
+    }
+}
+
+func TestRunMigratesLadderNamesToIDs(t *testing.T) {
+    dir := t.TempDir()
+    savePath := filepath.Join(dir, "save.json")
+    s, err := store.Open(savePath)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    onix := s.Add(api.Pokemon{Name: "onix"})
+    os.WriteFile(filepath.Join(dir, "ladder.json"), []byte(`[{"name": "onix", "rating": 1600, "wins": 3}]`), 0o644)
+
+    out := &bytes.Buffer{}
+    if err := Run(strings.NewReader("ladder\n"), out, WithSavePath(savePath)); err != nil {
+        t.Fatalf("Run error: %v", err)
+    }
+    if got := out.String(); !strings.Contains(got, "onix         "+onix.ShortID()+"   1600") {
+        t.Fatalf("expected onix's rating under its ID:\n%s", got)
+    }
+    l, err := ladder.Load(filepath.Join(dir, "ladder.json"))
+    if err != nil {
+        t.Fatalf("Load error: %v", err)
+    }
+    if p, ok := l.Get(onix.ID); !ok || p.Wins != 3 {
+        t.Fatalf("expected the migrated ladder to be saved, got %+v", p)
+    }
+}
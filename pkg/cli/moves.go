@@ -0,0 +1,147 @@
+package cli
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+var learnMethodOrder = map[string]int{
+    "level-up": 0,
+    "machine":  1,
+    "egg":      2,
+    "tutor":    3,
+}
+
+func (c *CLI) cmdMoves(ctx context.Context, args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: moves <pokemon> [version-group]")
+        return
+    }
+    name := args[0]
+    versionGroup := ""
+    if len(args) > 1 {
+        versionGroup = args[1]
+    }
+    p, err := c.api.FetchPokemon(ctx, name)
+    if err != nil {
+        c.printLookupErr(err, "pokemon", name)
+        return
+    }
+
+    moves := uniqueMoves(p.MovesFor(versionGroup))
+    if len(moves) == 0 {
+        if versionGroup != "" {
+            fmt.Fprintf(c.out, "%s learns no moves in %s\n", p.Name, versionGroup)
+            fmt.Fprintf(c.out, "version groups: %s\n", strings.Join(p.VersionGroups(), ", "))
+        } else {
+            fmt.Fprintf(c.out, "%s learns no moves\n", p.Name)
+        }
+        return
+    }
+
+    if versionGroup != "" {
+        fmt.Fprintf(c.out, "Moves for %s (%s):\n", p.Name, versionGroup)
+    } else {
+        fmt.Fprintf(c.out, "Moves for %s (all version groups):\n", p.Name)
+    }
+    method := ""
+    for _, m := range moves {
+        if m.LearnMethod != method {
+            method = m.LearnMethod
+            fmt.Fprintf(c.out, "  %s:\n", method)
+        }
+        if m.LearnMethod == "level-up" {
+            fmt.Fprintf(c.out, "    lv %3d  %s\n", m.LevelLearned, m.Name)
+        } else {
+            fmt.Fprintf(c.out, "    %s\n", m.Name)
+        }
+    }
+    if versionGroup == "" {
+        fmt.Fprintf(c.out, "filter by version group with: moves %s <%s>\n", p.Name, strings.Join(p.VersionGroups(), "|"))
+    }
+}
+
+// uniqueMoves keeps one entry per move and learn method, preferring the
+// lowest level, and sorts them for display.
+func uniqueMoves(moves []api.PokemonMove) []api.PokemonMove {
+    type key struct{ name, method string }
+    best := make(map[key]api.PokemonMove)
+    for _, m := range moves {
+        k := key{m.Name, m.LearnMethod}
+        if cur, ok := best[k]; !ok || m.LevelLearned < cur.LevelLearned {
+            best[k] = m
+        }
+    }
+    out := make([]api.PokemonMove, 0, len(best))
+    for _, m := range best {
+        out = append(out, m)
+    }
+    sort.Slice(out, func(i, j int) bool {
+        a, b := out[i], out[j]
+        if ra, rb := methodRank(a.LearnMethod), methodRank(b.LearnMethod); ra != rb {
+            return ra < rb
+        }
+        if a.LearnMethod != b.LearnMethod {
+            return a.LearnMethod < b.LearnMethod
+        }
+        if a.LevelLearned != b.LevelLearned {
+            return a.LevelLearned < b.LevelLearned
+        }
+        return a.Name < b.Name
+    })
+    return out
+}
+
+func methodRank(method string) int {
+    if r, ok := learnMethodOrder[method]; ok {
+        return r
+    }
+    return len(learnMethodOrder)
+}
+
+func (c *CLI) cmdMove(ctx context.Context, args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: move <name>")
+        return
+    }
+    name := args[0]
+    m, err := c.api.FetchMove(ctx, name)
+    if err != nil {
+        c.printLookupErr(err, "move", name)
+        return
+    }
+    fmt.Fprintf(c.out, "Name: %s\n", m.Name)
+    fmt.Fprintf(c.out, "Type: %s\n", m.Type)
+    fmt.Fprintf(c.out, "Damage class: %s\n", m.DamageClass)
+    fmt.Fprintf(c.out, "Power: %s\n", orDash(m.Power))
+    fmt.Fprintf(c.out, "Accuracy: %s\n", orDash(m.Accuracy))
+    fmt.Fprintf(c.out, "PP: %d\n", m.PP)
+    fmt.Fprintf(c.out, "Priority: %d\n", m.Priority)
+    if m.Effect != "" {
+        fmt.Fprintf(c.out, "Effect: %s\n", m.Effect)
+    }
+    if m.Ailment != "" {
+        fmt.Fprintf(c.out, "Ailment: %s (%s)\n", m.Ailment, chance(m.AilmentChance))
+    }
+    for _, sc := range m.StatChanges {
+        fmt.Fprintf(c.out, "Stat change: %s %+d (%s)\n", sc.Stat, sc.Change, chance(m.StatChance))
+    }
+}
+
+func chance(pct int) string {
+    if pct == 0 {
+        return "always"
+    }
+    return fmt.Sprintf("%d%%", pct)
+}
+
+func orDash(n int) string {
+    if n == 0 {
+        return "-"
+    }
+    return fmt.Sprint(n)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+    "strings"
+    "testing"
+)
+
+const testPikachuMovesJSON = `{
+  "name": "pikachu",
+  "moves": [
+    {"move": {"name": "thunderbolt"}, "version_group_details": [
+      {"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}}
+    ]},
+    {"move": {"name": "thunder-wave"}, "version_group_details": [
+      {"level_learned_at": 9, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+      {"level_learned_at": 8, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "yellow"}}
+    ]},
+    {"move": {"name": "thunder-shock"}, "version_group_details": [
+      {"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}
+    ]}
+  ]
+}`
+
+func TestMovesCommandFiltersByVersionGroup(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{"/pokemon/pikachu/": testPikachuMovesJSON})
+
+    c.runCommand("moves", []string{"pikachu", "red-blue"})
+    expected := "Moves for pikachu (red-blue):\n" +
+        "  level-up:\n" +
+        "    lv   1  thunder-shock\n" +
+        "    lv   9  thunder-wave\n" +
+        "  machine:\n" +
+        "    thunderbolt\n"
+    if out.String() != expected {
+        t.Fatalf("unexpected output:\n%s\nexpected:\n%s", out.String(), expected)
+    }
+
+    out.Reset()
+    c.runCommand("moves", []string{"pikachu"})
+    if !strings.Contains(out.String(), "lv   8  thunder-wave") {
+        t.Fatalf("expected unfiltered list to use the lowest level:\n%s", out.String())
+    }
+
+    out.Reset()
+    c.runCommand("moves", []string{"pikachu", "gold-silver"})
+    if !strings.Contains(out.String(), "version groups: red-blue, yellow") {
+        t.Fatalf("expected available version groups hint:\n%s", out.String())
+    }
+}
+
+func TestMoveCommand(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/move/swords-dance/": `{"name": "swords-dance", "power": null, "accuracy": null, "pp": 20, "type": {"name": "normal"}, "damage_class": {"name": "status"},
+  "effect_entries": [{"short_effect": "Raises the user's Attack by two stages.", "language": {"name": "en"}}]}`,
+    })
+
+    c.runCommand("move", []string{"swords-dance"})
+    got := out.String()
+    for _, want := range []string{"Damage class: status", "Power: -", "PP: 20", "Effect: Raises the user's Attack by two stages."} {
+        if !strings.Contains(got, want) {
+            t.Errorf("expected %q in output:\n%s", want, got)
+        }
+    }
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+)
+
+// owned looks up a caught Pokémon by species, nickname or ID, explaining
+// to the user when the reference is unknown or ambiguous.
+func (c *CLI) owned(ref string) (store.Entry, bool) {
+    e, err := c.store.Resolve(ref)
+    if err != nil {
+        fmt.Fprintln(c.out, err)
+        return store.Entry{}, false
+    }
+    return e, true
+}
+
+func (c *CLI) cmdNickname(args []string) {
+    if len(args) == 0 || len(args) > 2 {
+        fmt.Fprintln(c.out, "usage: nickname <pokemon> [name]")
+        return
+    }
+    e, ok := c.owned(strings.ToLower(args[0]))
+    if !ok {
+        return
+    }
+    nickname := ""
+    if len(args) == 2 {
+        nickname = args[1]
+    }
+    named, err := c.store.SetNickname(e.ID, nickname)
+    if err != nil {
+        fmt.Fprintln(c.out, err)
+        return
+    }
+    if c.ladder != nil {
+        c.ladder.Rename(e.ID, named.DisplayName())
+        c.saveLadder()
+    }
+    if nickname == "" {
+        fmt.Fprintf(c.out, "%s (%s) no longer has a nickname\n", e.DisplayName(), e.ShortID())
+        return
+    }
+    fmt.Fprintf(c.out, "%s (%s) is now called %s\n", e.Name, e.ShortID(), nickname)
+}
+
+func (c *CLI) cmdRelease(args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: release <pokemon>")
+        return
+    }
+    e, ok := c.owned(args[0])
+    if !ok {
+        return
+    }
+    if _, err := c.store.Release(e.ID); err != nil {
+        fmt.Fprintln(c.out, err)
+        return
+    }
+    fmt.Fprintf(c.out, "%s (%s) was released. Bye, %s!\n", e.DisplayName(), e.ShortID(), e.DisplayName())
+}
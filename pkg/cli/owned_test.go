@@ -0,0 +1,67 @@
+package cli
+
+import (
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestCatchingTwiceKeepsBothAndNicknamesTellThemApart(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon/pikachu/":         testPikachuJSON,
+        "/pokemon-species/pikachu/": testPikachuSpeciesJSON,
+    })
+    caught := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+    c.now = func() time.Time { return caught }
+    c.location = "viridian-forest-area"
+
+    for len(c.store.List()) < 2 {
+        c.runCommand("catch", []string{"pikachu", "masterball"})
+    }
+    entries := c.store.List()
+    first := entries[0]
+    if first.Location != "viridian-forest-area" || first.Ball != "masterball" || !first.CaughtAt.Equal(caught) {
+        t.Fatalf("unexpected catch details: %+v", first)
+    }
+
+    out.Reset()
+    c.runCommand("pokedex", nil)
+    if got := out.String(); !strings.Contains(got, " - pikachu x2") || !strings.Contains(got, first.ShortID()) {
+        t.Fatalf("expected pikachu grouped with IDs:\n%s", got)
+    }
+
+    out.Reset()
+    c.runCommand("inspect", []string{"pikachu"})
+    if got := out.String(); !strings.Contains(got, "you have 2 pikachu") {
+        t.Fatalf("expected an ambiguity message, got:\n%s", got)
+    }
+
+    out.Reset()
+    c.runCommand("nickname", []string{first.ShortID(), "Mr", "Sparky"})
+    if got := out.String(); !strings.Contains(got, "usage: nickname") {
+        t.Fatalf("expected multi-word nicknames to be refused, got:\n%s", got)
+    }
+
+    out.Reset()
+    c.runCommand("nickname", []string{first.ShortID(), "Sparky"})
+    c.runCommand("inspect", []string{"sparky"})
+    got := out.String()
+    for _, want := range []string{
+        "pikachu (" + first.ShortID() + ") is now called Sparky",
+        "Nickname: Sparky",
+        "ID: " + first.ID,
+        "Caught: 2024-05-01 12:00 in viridian-forest-area with a masterball",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+
+    out.Reset()
+    c.runCommand("release", []string{"sparky"})
+    c.runCommand("inspect", []string{"pikachu"})
+    got = out.String()
+    if !strings.Contains(got, "Sparky ("+first.ShortID()+") was released") || !strings.Contains(got, "ID: "+entries[1].ID) {
+        t.Fatalf("unexpected output after release:\n%s", got)
+    }
+}
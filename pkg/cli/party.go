@@ -0,0 +1,103 @@
+package cli
+
+import (
+    "fmt"
+    "strings"
+)
+
+const partyUsage = "usage: party [list | create <name> <pokemon>... | add <name> <pokemon> | remove <name> <pokemon> | delete <name>]"
+
+func (c *CLI) cmdParty(args []string) {
+    if len(args) == 0 || args[0] == "list" {
+        c.listParties()
+        return
+    }
+    if len(args) < 2 {
+        fmt.Fprintln(c.out, partyUsage)
+        return
+    }
+    sub, name := args[0], args[1]
+    switch sub {
+    case "create":
+        c.setParty(name, args[2:], "created")
+    case "add", "remove":
+        if len(args) < 3 {
+            fmt.Fprintln(c.out, partyUsage)
+            return
+        }
+        p, ok := c.store.Party(name)
+        if !ok {
+            fmt.Fprintf(c.out, "you have no party named %s\n", name)
+            return
+        }
+        members := p.Members
+        if sub == "add" {
+            members = append(members, args[2])
+        } else {
+            e, ok := c.owned(args[2])
+            if !ok {
+                return
+            }
+            members = without(members, e.ID)
+            if len(members) == len(p.Members) {
+                fmt.Fprintf(c.out, "%s is not in party %s\n", args[2], name)
+                return
+            }
+            if len(members) == 0 {
+                fmt.Fprintln(c.out, "a party needs at least one pokemon; use party delete instead")
+                return
+            }
+        }
+        c.setParty(name, members, "updated")
+    case "delete":
+        if !c.store.DeleteParty(name) {
+            fmt.Fprintf(c.out, "you have no party named %s\n", name)
+            return
+        }
+        fmt.Fprintf(c.out, "deleted party %s\n", name)
+    default:
+        fmt.Fprintln(c.out, partyUsage)
+    }
+}
+
+func (c *CLI) setParty(name string, members []string, verb string) {
+    if err := c.store.SetParty(name, members); err != nil {
+        fmt.Fprintln(c.out, err)
+        return
+    }
+    p, _ := c.store.Party(name)
+    fmt.Fprintf(c.out, "%s party %s: %s\n", verb, name, c.memberNames(p.Members))
+}
+
+// memberNames lists party members by nickname or species.
+func (c *CLI) memberNames(ids []string) string {
+    names := make([]string, 0, len(ids))
+    for _, id := range ids {
+        e, _ := c.store.Get(id)
+        names = append(names, e.DisplayName())
+    }
+    return strings.Join(names, ", ")
+}
+
+func (c *CLI) listParties() {
+    names := c.store.PartyNames()
+    if len(names) == 0 {
+        fmt.Fprintln(c.out, "you have no parties; create one with party create <name> <pokemon>...")
+        return
+    }
+    fmt.Fprintln(c.out, "Your parties:")
+    for _, name := range names {
+        p, _ := c.store.Party(name)
+        fmt.Fprintf(c.out, " - %s: %s\n", name, c.memberNames(p.Members))
+    }
+}
+
+func without(list []string, drop string) []string {
+    var out []string
+    for _, s := range list {
+        if s != drop {
+            out = append(out, s)
+        }
+    }
+    return out
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func TestPartyCommands(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    for _, n := range []string{"bulbasaur", "charmander", "squirtle"} {
+        c.store.Add(api.Pokemon{Name: n})
+    }
+
+    c.cmdParty([]string{"create", "red", "bulbasaur", "charmander"})
+    c.cmdParty([]string{"add", "red", "squirtle"})
+    c.cmdParty([]string{"remove", "red", "bulbasaur"})
+    c.cmdParty([]string{"add", "red", "mew"})
+    c.cmdParty(nil)
+    got := out.String()
+    for _, want := range []string{
+        "created party red: bulbasaur, charmander",
+        "updated party red: bulbasaur, charmander, squirtle",
+        "updated party red: charmander, squirtle",
+        "you have not caught mew",
+        " - red: charmander, squirtle",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+
+    out.Reset()
+    c.cmdParty([]string{"delete", "red"})
+    c.cmdParty([]string{"list"})
+    if got := out.String(); !strings.Contains(got, "deleted party red") || !strings.Contains(got, "you have no parties") {
+        t.Fatalf("unexpected output after delete:\n%s", got)
+    }
+}
+
+func TestTeamBattle(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    stats := func(hp, atk int) map[string]int {
+        return map[string]int{"hp": hp, "attack": atk, "defense": 10, "speed": 10}
+    }
+    c.store.Add(api.Pokemon{Name: "weak1", Stats: stats(10, 1)})
+    c.store.Add(api.Pokemon{Name: "weak2", Stats: stats(10, 1)})
+    c.store.Add(api.Pokemon{Name: "strong1", Stats: stats(200, 300)})
+    c.store.Add(api.Pokemon{Name: "strong2", Stats: stats(200, 300)})
+    if err := c.store.SetParty("underdogs", []string{"weak1", "weak2"}); err != nil {
+        t.Fatal(err)
+    }
+    if err := c.store.SetParty("champs", []string{"strong1", "strong2"}); err != nil {
+        t.Fatal(err)
+    }
+
+    c.cmdBattle(context.Background(), []string{"team", "underdogs", "champs"})
+    got := out.String()
+    for _, want := range []string{
+        "Team battle: underdogs vs champs",
+        "underdogs sent out weak2!",
+        "champs wins!",
+        "Summary after",
+        "weak1        KOs: 0",
+        "fainted",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+
+    out.Reset()
+    c.cmdBattle(context.Background(), []string{"team", "underdogs", "nobody"})
+    if got := out.String(); !strings.Contains(got, "you have no party named nobody") {
+        t.Fatalf("unexpected output: %s", got)
+    }
+}
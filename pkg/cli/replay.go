@@ -0,0 +1,68 @@
+package cli
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/internal/atomicfile"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/battle"
+)
+
+// saveReplay writes rep to the replay directory. Recording is skipped when
+// no directory is configured.
+func (c *CLI) saveReplay(rep *battle.Replay) {
+    if c.replayDir == "" {
+        return
+    }
+    data, err := json.MarshalIndent(rep, "", "  ")
+    if err != nil {
+        fmt.Fprintf(c.out, "could not record replay: %v\n", err)
+        return
+    }
+    name := fmt.Sprintf("battle-%s-%06x.json", c.clock().Format("20060102-150405"), uint64(rep.Seed)&0xffffff)
+    path := filepath.Join(c.replayDir, name)
+    if err := atomicfile.WriteFile(path, data, 0o644); err != nil {
+        fmt.Fprintf(c.out, "could not record replay: %v\n", err)
+        return
+    }
+    fmt.Fprintf(c.out, "replay saved to %s\n", path)
+}
+
+func (c *CLI) cmdReplay(args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: replay <file>")
+        return
+    }
+    path := args[0]
+    if _, err := os.Stat(path); os.IsNotExist(err) && c.replayDir != "" {
+        path = filepath.Join(c.replayDir, path)
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        fmt.Fprintf(c.out, "could not read replay: %v\n", err)
+        return
+    }
+    var rep battle.Replay
+    if err := json.Unmarshal(data, &rep); err != nil {
+        fmt.Fprintf(c.out, "%s is not a valid replay: %v\n", path, err)
+        return
+    }
+
+    fmt.Fprintf(c.out, "Replay: %s vs %s (seed %d, engine v%d)\n", rep.Sides[0].Name, rep.Sides[1].Name, rep.Seed, rep.EngineVersion)
+    log, res := rep.Play()
+    fmt.Fprint(c.out, log)
+    if diff := rep.Divergence(log); diff != "" {
+        fmt.Fprintf(c.out, "replay diverged at %s\n", diff)
+        if rep.EngineVersion != battle.EngineVersion {
+            fmt.Fprintf(c.out, "it was recorded with engine v%d; this is engine v%d\n", rep.EngineVersion, battle.EngineVersion)
+        }
+        return
+    }
+    if res != rep.Result {
+        fmt.Fprintf(c.out, "replay diverged: recorded result %+v, replayed %+v\n", rep.Result, res)
+        return
+    }
+    fmt.Fprintf(c.out, "replay verified: %d turns, log is byte-identical\n", res.Turns)
+}
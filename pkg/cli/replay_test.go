@@ -0,0 +1,44 @@
+package cli
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func TestBattleRecordsReplay(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    c.replayDir = t.TempDir()
+    c.store.Add(api.Pokemon{Name: "pidgey", Stats: map[string]int{"hp": 40, "attack": 45, "defense": 40, "speed": 56}})
+    c.store.Add(api.Pokemon{Name: "rattata", Stats: map[string]int{"hp": 30, "attack": 56, "defense": 35, "speed": 72}})
+
+    c.cmdBattle(context.Background(), []string{"pidgey", "rattata"})
+    files, _ := filepath.Glob(filepath.Join(c.replayDir, "*.json"))
+    if len(files) != 1 || !strings.Contains(out.String(), "replay saved to "+files[0]) {
+        t.Fatalf("expected one replay file, got %v; output:\n%s", files, out.String())
+    }
+    battleLog := out.String()
+
+    out.Reset()
+    c.cmdReplay([]string{filepath.Base(files[0])})
+    got := out.String()
+    if !strings.Contains(got, "replay verified") {
+        t.Fatalf("expected replay to verify, got:\n%s", got)
+    }
+    if !strings.Contains(battleLog, strings.Split(got, "\n")[1]) {
+        t.Fatalf("expected the replayed log to match the battle output")
+    }
+
+    data, _ := os.ReadFile(files[0])
+    data = []byte(strings.Replace(string(data), "used tackle!", "used splash!", 1))
+    os.WriteFile(files[0], data, 0o644)
+    out.Reset()
+    c.cmdReplay([]string{files[0]})
+    if got := out.String(); !strings.Contains(got, "replay diverged at line 1") {
+        t.Fatalf("expected divergence to be flagged, got:\n%s", got)
+    }
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+    "context"
+    "fmt"
+    "math/rand"
+    "runtime"
+    "strconv"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/battle"
+)
+
+const defaultRuns = 1000
+const maxRuns = 1000000
+
+const simulateUsage = "usage: simulate <pokemon1>[,<pokemon>...] <pokemon2>[,<pokemon>...] [--runs <n>] [--ai <strategy>]"
+
+func (c *CLI) cmdSimulate(ctx context.Context, args []string) {
+    aiName, args, ok := c.parseAIFlag(args, battle.DefaultAI)
+    if !ok {
+        return
+    }
+    runs := defaultRuns
+    var names []string
+    for i := 0; i < len(args); i++ {
+        if args[i] != "--runs" {
+            names = append(names, args[i])
+            continue
+        }
+        if i+1 == len(args) {
+            fmt.Fprintln(c.out, simulateUsage)
+            return
+        }
+        i++
+        n, err := strconv.Atoi(args[i])
+        if err != nil || n < 1 || n > maxRuns {
+            fmt.Fprintf(c.out, "--runs needs a number between 1 and %d\n", maxRuns)
+            return
+        }
+        runs = n
+    }
+    if len(names) < 2 || !validTeamArgs(names[:2]) {
+        fmt.Fprintln(c.out, simulateUsage)
+        return
+    }
+    if _, err := c.newAI(aiName); err != nil {
+        return
+    }
+
+    var specs [2][]battle.CombatantSpec
+    for i, arg := range names[:2] {
+        team, ok := c.teamEntries(strings.Split(arg, ","))
+        if !ok {
+            return
+        }
+        side, err := c.battleSide(ctx, arg, team)
+        if err != nil {
+            c.printErr(err)
+            return
+        }
+        for _, member := range side.Team {
+            specs[i] = append(specs[i], battle.Spec(member))
+        }
+    }
+
+    seeds := make([]int64, runs)
+    for i := range seeds {
+        seeds[i] = c.rng.Int63()
+    }
+    setup := func(rng *rand.Rand) ([2]*battle.Side, [2]battle.Controller) {
+        var sides [2]*battle.Side
+        for i, team := range specs {
+            sides[i] = battle.NewSide(names[i])
+            for _, spec := range team {
+                sides[i].Team = append(sides[i].Team, spec.Combatant())
+            }
+        }
+        ai, _ := battle.NewAI(aiName, rng)
+        return sides, [2]battle.Controller{ai, ai}
+    }
+
+    fmt.Fprintf(c.out, "Simulating %s vs %s: %d runs with the %s AI\n", names[0], names[1], runs, aiName)
+    res, err := battle.Simulate(ctx, seeds, runtime.GOMAXPROCS(0), c.typeChart(), setup)
+    if err != nil {
+        c.printErr(err)
+        return
+    }
+    for i := range specs {
+        lo, hi := res.Interval95(i)
+        fmt.Fprintf(c.out, "%s wins %.1f%% (95%% CI %.1f%%-%.1f%%)\n", names[i], 100*res.WinRate(i), 100*lo, 100*hi)
+    }
+    fmt.Fprintf(c.out, "draws %.1f%%\n", 100*res.DrawRate())
+    fmt.Fprintf(c.out, "mean turns %.1f\n", res.MeanTurns())
+}
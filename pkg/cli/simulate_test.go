@@ -0,0 +1,38 @@
+package cli
+
+import (
+    "context"
+    "strings"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func TestSimulate(t *testing.T) {
+    c, out := newTestCLI(t, nil)
+    c.store.Add(api.Pokemon{Name: "weak", Stats: map[string]int{"hp": 10, "attack": 1, "defense": 1, "speed": 10}})
+    c.store.Add(api.Pokemon{Name: "strong", Stats: map[string]int{"hp": 100, "attack": 1000, "defense": 1, "speed": 5}})
+
+    c.cmdSimulate(context.Background(), []string{"weak", "strong", "--runs", "200"})
+    got := out.String()
+    for _, want := range []string{
+        "Simulating weak vs strong: 200 runs with the random AI",
+        "weak wins 0.0% (95% CI 0.0%-1.9%)",
+        "strong wins 100.0%",
+        "draws 0.0%",
+        "mean turns 1.0",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+    if strings.Contains(got, "used tackle") {
+        t.Fatalf("expected no per-hit log lines:\n%s", got)
+    }
+
+    out.Reset()
+    c.cmdSimulate(context.Background(), []string{"weak", "strong", "--runs", "0"})
+    if !strings.Contains(out.String(), "--runs needs a number") {
+        t.Fatalf("unexpected output %q", out.String())
+    }
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func (c *CLI) cmdSpecies(ctx context.Context, args []string) {
+    if len(args) == 0 {
+        fmt.Fprintln(c.out, "usage: species <pokemon>")
+        return
+    }
+    name := args[0]
+    s, err := c.api.FetchPokemonSpecies(ctx, name)
+    if err != nil {
+        c.printLookupErr(err, "species", name)
+        return
+    }
+
+    fmt.Fprintf(c.out, "Name: %s\n", s.Name)
+    if genus := s.Genus("en"); genus != "" {
+        fmt.Fprintf(c.out, "Genus: %s\n", genus)
+    }
+    fmt.Fprintf(c.out, "Capture rate: %d\n", s.CaptureRate)
+    fmt.Fprintf(c.out, "Base happiness: %d\n", s.BaseHappiness)
+    fmt.Fprintf(c.out, "Legendary: %s\n", yesNo(s.IsLegendary))
+    fmt.Fprintf(c.out, "Mythical: %s\n", yesNo(s.IsMythical))
+    fmt.Fprintf(c.out, "Growth rate: %s\n", s.GrowthRate)
+    if len(s.EggGroups) > 0 {
+        fmt.Fprintf(c.out, "Egg groups: %s\n", strings.Join(s.EggGroups, ", "))
+    }
+    if s.Habitat != "" {
+        fmt.Fprintf(c.out, "Habitat: %s\n", s.Habitat)
+    }
+    fmt.Fprintf(c.out, "Color: %s\n", s.Color)
+    if text := s.FlavorText("en"); text != "" {
+        fmt.Fprintln(c.out, "Pokédex entry:")
+        fmt.Fprintf(c.out, "  %s\n", text)
+    }
+}
+
+func yesNo(b bool) string {
+    if b {
+        return "yes"
+    }
+    return "no"
+}
+
+// lookupSpecies accepts either a species name or a Pokémon form name such as
+// "deoxys-attack", falling back to the form's species when needed.
+func (c *CLI) lookupSpecies(ctx context.Context, name string) (*api.PokemonSpecies, error) {
+    s, err := c.api.FetchPokemonSpecies(ctx, name)
+    if err == nil || !errors.Is(err, api.ErrNotFound) {
+        return s, err
+    }
+    p, perr := c.api.FetchPokemon(ctx, name)
+    if perr != nil {
+        return nil, err
+    }
+    return c.api.FetchPokemonSpecies(ctx, p.SpeciesName())
+}
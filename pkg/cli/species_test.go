@@ -0,0 +1,78 @@
+package cli
+
+import (
+    "strings"
+    "testing"
+)
+
+const testPikachuJSON = `{
+  "name": "pikachu",
+  "species": {"name": "pikachu"},
+  "base_experience": 112,
+  "height": 4,
+  "weight": 60,
+  "stats": [
+    {"base_stat": 35, "stat": {"name": "hp"}},
+    {"base_stat": 55, "stat": {"name": "attack"}}
+  ],
+  "types": [{"type": {"name": "electric"}}]
+}`
+
+const testPikachuSpeciesJSON = `{
+  "name": "pikachu",
+  "capture_rate": 190,
+  "base_happiness": 50,
+  "genera": [{"genus": "Mouse Pokémon", "language": {"name": "en"}}],
+  "flavor_text_entries": [
+    {"flavor_text": "It keeps its tail\nraised to monitor\fits surroundings.", "language": {"name": "en"}, "version": {"name": "yellow"}}
+  ],
+  "evolution_chain": {"url": "/evolution-chain/10/"},
+  "growth_rate": {"name": "medium"},
+  "egg_groups": [{"name": "ground"}, {"name": "fairy"}],
+  "habitat": {"name": "forest"},
+  "color": {"name": "yellow"}
+}`
+
+func TestSpeciesCommand(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon-species/pikachu/": testPikachuSpeciesJSON,
+    })
+
+    c.runCommand("species", []string{"pikachu"})
+    got := out.String()
+    for _, want := range []string{
+        "Genus: Mouse Pokémon",
+        "Capture rate: 190",
+        "Legendary: no",
+        "Egg groups: ground, fairy",
+        "It keeps its tail raised to monitor its surroundings.",
+    } {
+        if !strings.Contains(got, want) {
+            t.Errorf("expected %q in output:\n%s", want, got)
+        }
+    }
+
+    out.Reset()
+    c.runCommand("species", []string{"missingno"})
+    if !strings.Contains(out.String(), `no species named "missingno" was found`) {
+        t.Errorf("expected not found message, got:\n%s", out.String())
+    }
+}
+
+func TestInspectShowsFlavorText(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{
+        "/pokemon/pikachu/":         testPikachuJSON,
+        "/pokemon-species/pikachu/": testPikachuSpeciesJSON,
+    })
+
+    c.runCommand("catch", []string{"pikachu", "masterball"})
+    if !strings.Contains(out.String(), "pikachu was caught!") {
+        t.Fatalf("expected catch to succeed:\n%s", out.String())
+    }
+    out.Reset()
+    c.runCommand("inspect", []string{"pikachu"})
+    got := out.String()
+    if !strings.Contains(got, "Pokédex entry:\n  It keeps its tail raised to monitor its surroundings.") {
+        t.Fatalf("expected flavor text in inspect output:\n%s", got)
+    }
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/battle"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/store"
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/tournament"
+)
+
+const defaultBestOf = 3
+const defaultTournamentAI = "typeaware"
+
+const tournamentUsage = "usage: tournament [elimination|roundrobin] [--best-of <n>] [--ai <strategy>] [--type <type>] [pokemon...]"
+
+func (c *CLI) cmdTournament(ctx context.Context, args []string) {
+    aiName, args, ok := c.parseAIFlag(args, defaultTournamentAI)
+    if !ok {
+        return
+    }
+    format, bestOf, typeFilter := "elimination", defaultBestOf, ""
+    var names []string
+    for i := 0; i < len(args); i++ {
+        switch arg := args[i]; arg {
+        case "elimination", "roundrobin":
+            format = arg
+        case "--best-of", "--type":
+            if i+1 == len(args) {
+                fmt.Fprintln(c.out, tournamentUsage)
+                return
+            }
+            i++
+            if arg == "--type" {
+                typeFilter = args[i]
+                continue
+            }
+            n, err := strconv.Atoi(args[i])
+            if err != nil || n < 1 || n%2 == 0 {
+                fmt.Fprintln(c.out, "--best-of needs a positive odd number")
+                return
+            }
+            bestOf = n
+        default:
+            names = append(names, arg)
+        }
+    }
+    ai, err := c.newAI(aiName)
+    if err != nil {
+        return
+    }
+
+    entries, ok := c.tournamentEntries(names, typeFilter)
+    if !ok {
+        return
+    }
+    if len(entries) < 2 {
+        fmt.Fprintln(c.out, "a tournament needs at least two caught pokemon")
+        return
+    }
+    specs, byLabel, seeds, err := c.tournamentSpecs(ctx, entries)
+    if err != nil {
+        c.printErr(err)
+        return
+    }
+
+    game := func(a, b string) int {
+        if ctx.Err() != nil {
+            return battle.Draw
+        }
+        sides := [2]*battle.Side{battle.NewSide(a, specs[a].Combatant()), battle.NewSide(b, specs[b].Combatant())}
+        _, res, rep := c.playBattle(sides, [2]battle.Controller{ai, ai}, nil)
+        c.settleBattle(ctx, sides, [2][]store.Entry{{byLabel[a]}, {byLabel[b]}}, res.Winner, rep)
+        return res.Winner
+    }
+    defer c.saveLadder()
+
+    formatName := "single elimination"
+    if format == "roundrobin" {
+        formatName = "round robin"
+    }
+    fmt.Fprintf(c.out, "Tournament: %s, %d entrants, best of %d\n", formatName, len(seeds), bestOf)
+    if format == "roundrobin" {
+        series, standings := tournament.RoundRobin(seeds, bestOf, game)
+        if ctx.Err() != nil {
+            c.printErr(ctx.Err())
+            return
+        }
+        for _, s := range series {
+            fmt.Fprintf(c.out, "  %s\n", s)
+        }
+        c.printStandings(standings)
+        return
+    }
+
+    br := tournament.SingleElimination(seeds, bestOf, game)
+    if ctx.Err() != nil {
+        c.printErr(ctx.Err())
+        return
+    }
+    for _, r := range br.Rounds {
+        fmt.Fprintln(c.out, r.Name)
+        for _, s := range r.Series {
+            fmt.Fprintf(c.out, "  %s\n", s)
+        }
+        if len(r.Byes) > 0 {
+            fmt.Fprintf(c.out, "  byes: %s\n", strings.Join(r.Byes, ", "))
+        }
+    }
+    fmt.Fprintf(c.out, "Champion: %s\n", br.Champion)
+}
+
+// tournamentEntries picks the named Pokémon, or the whole Pokédex when no
+// names are given, keeping only those of typeFilter if set.
+func (c *CLI) tournamentEntries(names []string, typeFilter string) ([]store.Entry, bool) {
+    all := c.store.List()
+    if len(names) > 0 {
+        all = nil
+        seen := make(map[string]bool)
+        for _, name := range names {
+            e, ok := c.owned(name)
+            if !ok {
+                return nil, false
+            }
+            if seen[e.ID] {
+                continue
+            }
+            seen[e.ID] = true
+            all = append(all, e)
+        }
+    }
+    var entries []store.Entry
+    for _, e := range all {
+        if typeFilter != "" && !hasType(e.Types, typeFilter) {
+            continue
+        }
+        entries = append(entries, e)
+    }
+    return entries, true
+}
+
+func hasType(types []string, t string) bool {
+    for _, own := range types {
+        if own == t {
+            return true
+        }
+    }
+    return false
+}
+
+// tournamentSpecs builds every entrant's combatant once and seeds the
+// field by level, then base stat total. Entrants are named by nickname or
+// species, with the ID appended when two would share a name.
+func (c *CLI) tournamentSpecs(ctx context.Context, entries []store.Entry) (map[string]battle.CombatantSpec, map[string]store.Entry, []string, error) {
+    side, err := c.battleSide(ctx, "", entries)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+    count := make(map[string]int)
+    for _, e := range entries {
+        count[e.DisplayName()]++
+    }
+    labels := make(map[string]string, len(entries))
+    specs := make(map[string]battle.CombatantSpec, len(entries))
+    byLabel := make(map[string]store.Entry, len(entries))
+    for i, member := range side.Team {
+        e := entries[i]
+        label := e.DisplayName()
+        if count[label] > 1 {
+            label += "#" + e.ShortID()
+        }
+        member.Name = label
+        labels[e.ID] = label
+        specs[label] = battle.Spec(member)
+        byLabel[label] = e
+    }
+
+    sort.Slice(entries, func(i, j int) bool {
+        a, b := entries[i], entries[j]
+        if a.Level != b.Level {
+            return a.Level > b.Level
+        }
+        if ta, tb := statTotal(a.Stats), statTotal(b.Stats); ta != tb {
+            return ta > tb
+        }
+        return labels[a.ID] < labels[b.ID]
+    })
+    seeds := make([]string, 0, len(entries))
+    for _, e := range entries {
+        seeds = append(seeds, labels[e.ID])
+    }
+    return specs, byLabel, seeds, nil
+}
+
+func statTotal(stats map[string]int) int {
+    total := 0
+    for _, v := range stats {
+        total += v
+    }
+    return total
+}
+
+func (c *CLI) printStandings(standings []tournament.Standing) {
+    fmt.Fprintf(c.out, "%-4s %-12s %3s %3s %3s %7s %4s\n", "Rank", "Pokemon", "W", "D", "L", "Games", "Pts")
+    for i, s := range standings {
+        games := fmt.Sprintf("%d-%d", s.GamesWon, s.GamesLost)
+        fmt.Fprintf(c.out, "%-4d %-12s %3d %3d %3d %7s %4d\n", i+1, s.Name, s.Wins, s.Draws, s.Losses, games, s.Points())
+    }
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+    "context"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func addTournamentField(c *CLI) {
+    for name, atk := range map[string]int{"mewtwo": 500, "onix": 200, "magikarp": 1} {
+        c.store.Add(api.Pokemon{Name: name, Types: []string{"normal"}, Stats: map[string]int{"hp": 100, "attack": atk, "defense": 50, "speed": atk}})
+    }
+    c.store.Add(api.Pokemon{Name: "ponyta", Types: []string{"fire"}, Stats: map[string]int{"hp": 100, "attack": 100, "defense": 50, "speed": 100}})
+}
+
+func TestTournamentElimination(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{"/type/normal/": `{"name": "normal"}`, "/type/fire/": `{"name": "fire"}`})
+    addTournamentField(c)
+
+    c.cmdTournament(context.Background(), []string{"--best-of", "3"})
+    got := out.String()
+    for _, want := range []string{
+        "Tournament: single elimination, 4 entrants, best of 3",
+        "Semifinals\n  mewtwo def. magikarp 2-0",
+        "Final\n  mewtwo def.",
+        "Champion: mewtwo",
+    } {
+        if !strings.Contains(got, want) {
+            t.Fatalf("expected %q in output:\n%s", want, got)
+        }
+    }
+}
+
+func TestTournamentRoundRobinWithTypeFilter(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{"/type/normal/": `{"name": "normal"}`})
+    addTournamentField(c)
+
+    c.cmdTournament(context.Background(), []string{"roundrobin", "--type", "normal", "--best-of", "1"})
+    got := out.String()
+    if !strings.Contains(got, "Tournament: round robin, 3 entrants, best of 1") || strings.Contains(got, "ponyta") {
+        t.Fatalf("expected only normal types to enter:\n%s", got)
+    }
+    if !strings.Contains(got, "1    mewtwo") || !strings.Contains(got, "3    magikarp") {
+        t.Fatalf("unexpected standings:\n%s", got)
+    }
+
+    out.Reset()
+    c.cmdTournament(context.Background(), []string{"--best-of", "2"})
+    if !strings.Contains(out.String(), "--best-of needs a positive odd number") {
+        t.Fatalf("expected best-of validation, got %q", out.String())
+    }
+}
+
+func TestTournamentEntersEachPokemonOnce(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{"/type/normal/": `{"name": "normal"}`})
+    addTournamentField(c)
+    onix, _ := c.store.Get("onix")
+
+    c.cmdTournament(context.Background(), []string{"roundrobin", "onix", onix.ID, "magikarp", "--best-of", "1"})
+    got := out.String()
+    if !strings.Contains(got, "Tournament: round robin, 2 entrants, best of 1") || strings.Contains(got, "onix#") {
+        t.Fatalf("expected onix to enter once:\n%s", got)
+    }
+
+    out.Reset()
+    c.cmdTournament(context.Background(), []string{"onix", onix.ID})
+    if !strings.Contains(out.String(), "a tournament needs at least two caught pokemon") {
+        t.Fatalf("expected a single pokemon to be refused, got %q", out.String())
+    }
+}
+
+func TestTournamentGamesRewardAndRecordReplays(t *testing.T) {
+    c, out := newTestCLI(t, map[string]string{"/type/normal/": `{"name": "normal"}`})
+    c.replayDir = t.TempDir()
+    c.store.Add(api.Pokemon{Name: "mewtwo", BaseExperience: 100, Types: []string{"normal"}, Stats: map[string]int{"hp": 100, "attack": 500, "defense": 50, "speed": 500}})
+    c.store.Add(api.Pokemon{Name: "magikarp", BaseExperience: 40, Types: []string{"normal"}, Stats: map[string]int{"hp": 100, "attack": 1, "defense": 50, "speed": 1}})
+
+    c.cmdTournament(context.Background(), []string{"--best-of", "3"})
+    if got := out.String(); !strings.Contains(got, "Champion: mewtwo") || !strings.Contains(got, "mewtwo gained 28 experience.") {
+        t.Fatalf("expected the champion to earn experience:\n%s", got)
+    }
+    files, _ := filepath.Glob(filepath.Join(c.replayDir, "*.json"))
+    if len(files) != 2 {
+        t.Fatalf("expected a replay for each game, got %v", files)
+    }
+    if e, _ := c.store.Get("mewtwo"); e.Experience == 0 {
+        t.Fatalf("expected mewtwo's experience to be saved, got %+v", e)
+    }
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+    "context"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+// loadTypes makes sure the session's type chart knows every given type,
+// fetching only the ones it has not seen yet.
+func (c *CLI) loadTypes(ctx context.Context, types ...string) error {
+    if c.types == nil {
+        c.types = api.NewTypeChart()
+    }
+    for _, name := range types {
+        if c.types.Has(name) {
+            continue
+        }
+        t, err := c.api.FetchType(ctx, name)
+        if err != nil {
+            return err
+        }
+        c.types.Add(t)
+    }
+    return nil
+}
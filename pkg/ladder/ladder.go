@@ -0,0 +1,264 @@
+package ladder
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "os"
+    "sort"
+    "sync"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/internal/atomicfile"
+)
+
+const DefaultRating = 1500.0
+
+// K is how far a single result can move a rating.
+const K = 32.0
+
+const (
+    Win  = "win"
+    Loss = "loss"
+    Draw = "draw"
+)
+
+// Player is one owned Pokémon's record, keyed by its ID. Name is only a
+// label and follows nicknames and evolutions.
+type Player struct {
+    ID      string  `json:"id"`
+    Name    string  `json:"name"`
+    Rating  float64 `json:"rating"`
+    Wins    int     `json:"wins"`
+    Losses  int     `json:"losses"`
+    Draws   int     `json:"draws"`
+    History []Point `json:"history"`
+}
+
+// Point is a player's rating right after one battle.
+type Point struct {
+    At       time.Time `json:"at"`
+    Rating   float64   `json:"rating"`
+    Opponent string    `json:"opponent"`
+    Outcome  string    `json:"outcome"`
+}
+
+// Entrant is a Pokémon taking part in a rated battle.
+type Entrant struct {
+    ID   string
+    Name string
+}
+
+type Ladder struct {
+    mu      sync.Mutex
+    path    string
+    players map[string]*Player
+}
+
+func New(path string) *Ladder {
+    return &Ladder{path: path, players: make(map[string]*Player)}
+}
+
+// Load reads the ladder at path. A missing file is an empty ladder. Files
+// written before players had IDs keep their names as keys until Migrate is
+// called.
+func Load(path string) (*Ladder, error) {
+    l := New(path)
+    data, err := os.ReadFile(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return l, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    var players []*Player
+    if err := json.Unmarshal(data, &players); err != nil {
+        return nil, fmt.Errorf("%s is corrupt: %w", path, err)
+    }
+    for _, p := range players {
+        if p.ID == "" {
+            l.players[p.Name] = p
+            continue
+        }
+        l.players[p.ID] = p
+    }
+    return l, nil
+}
+
+// Migrate re-keys players saved by name alone. resolve maps an old name to
+// the Pokémon it now means; names it cannot resolve stay as they are, keyed
+// by name. It reports whether anything changed.
+func (l *Ladder) Migrate(resolve func(name string) (Entrant, bool)) bool {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    changed := false
+    for key, p := range l.players {
+        if p.ID != "" {
+            continue
+        }
+        changed = true
+        p.ID = key
+        e, ok := resolve(p.Name)
+        if !ok {
+            continue
+        }
+        if _, taken := l.players[e.ID]; taken {
+            continue
+        }
+        delete(l.players, key)
+        p.ID, p.Name = e.ID, e.Name
+        l.players[e.ID] = p
+    }
+    return changed
+}
+
+func (l *Ladder) Save() error {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if l.path == "" {
+        return nil
+    }
+    data, err := json.MarshalIndent(l.sorted(), "", "  ")
+    if err != nil {
+        return err
+    }
+    return atomicfile.WriteFile(l.path, data, 0o644)
+}
+
+// Expected is the probability that a player rated a beats one rated b.
+func Expected(a, b float64) float64 {
+    return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Record updates every member of both teams. Each member is rated against
+// the average pre-battle rating of the other team; winner is 0, 1 or -1
+// for a draw. Battles with an empty side, or where a Pokémon fought
+// itself, are not rated.
+func (l *Ladder) Record(teams [2][]Entrant, winner int, at time.Time) {
+    if len(teams[0]) == 0 || len(teams[1]) == 0 {
+        return
+    }
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    for _, a := range teams[0] {
+        for _, b := range teams[1] {
+            if a.ID == b.ID {
+                return
+            }
+        }
+    }
+
+    var avg [2]float64
+    for i, team := range teams {
+        for _, e := range team {
+            avg[i] += l.player(e).Rating
+        }
+        if len(team) > 0 {
+            avg[i] /= float64(len(team))
+        }
+    }
+
+    deltas := make(map[string]float64)
+    for i, team := range teams {
+        score := 0.5
+        switch winner {
+        case i:
+            score = 1
+        case 1 - i:
+            score = 0
+        }
+        for _, e := range team {
+            deltas[e.ID] = K * (score - Expected(l.player(e).Rating, avg[1-i]))
+        }
+    }
+
+    for i, team := range teams {
+        opponent := teamLabel(teams[1-i])
+        for _, e := range team {
+            p := l.player(e)
+            p.Rating += deltas[e.ID]
+            outcome := Draw
+            switch winner {
+            case i:
+                outcome = Win
+                p.Wins++
+            case 1 - i:
+                outcome = Loss
+                p.Losses++
+            default:
+                p.Draws++
+            }
+            p.History = append(p.History, Point{At: at, Rating: p.Rating, Opponent: opponent, Outcome: outcome})
+        }
+    }
+}
+
+func teamLabel(team []Entrant) string {
+    switch len(team) {
+    case 0:
+        return "nobody"
+    case 1:
+        return team[0].Name
+    }
+    return fmt.Sprintf("%s and %d others", team[0].Name, len(team)-1)
+}
+
+// player returns e's record, creating it at the default rating and
+// refreshing its label. The caller must hold l.mu.
+func (l *Ladder) player(e Entrant) *Player {
+    p, ok := l.players[e.ID]
+    if !ok {
+        p = &Player{ID: e.ID, Rating: DefaultRating}
+        l.players[e.ID] = p
+    }
+    p.Name = e.Name
+    return p
+}
+
+func (l *Ladder) Get(id string) (Player, bool) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    p, ok := l.players[id]
+    if !ok {
+        return Player{}, false
+    }
+    return *p, true
+}
+
+// Rename changes a player's label, for example after it evolves.
+func (l *Ladder) Rename(id, name string) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if p, ok := l.players[id]; ok {
+        p.Name = name
+    }
+}
+
+// Rankings lists players from highest to lowest rating.
+func (l *Ladder) Rankings() []Player {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    var out []Player
+    for _, p := range l.sorted() {
+        out = append(out, *p)
+    }
+    return out
+}
+
+func (l *Ladder) sorted() []*Player {
+    players := make([]*Player, 0, len(l.players))
+    for _, p := range l.players {
+        players = append(players, p)
+    }
+    sort.Slice(players, func(i, j int) bool {
+        if players[i].Rating != players[j].Rating {
+            return players[i].Rating > players[j].Rating
+        }
+        if players[i].Name != players[j].Name {
+            return players[i].Name < players[j].Name
+        }
+        return players[i].ID < players[j].ID
+    })
+    return players
+}
@@ -0,0 +1,134 @@
+package ladder
+
+import (
+    "math"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestExpected(t *testing.T) {
+    if got := Expected(1500, 1500); got != 0.5 {
+        t.Fatalf("expected even odds, got %v", got)
+    }
+    if got := Expected(1900, 1500); math.Abs(got-0.909) > 0.001 {
+        t.Fatalf("expected ~0.909 for a 400 point edge, got %v", got)
+    }
+}
+
+// team names each entrant after its ID.
+func team(ids ...string) []Entrant {
+    var t []Entrant
+    for _, id := range ids {
+        t = append(t, Entrant{ID: id, Name: id})
+    }
+    return t
+}
+
+func TestRecordUpdatesRatingsAndRecords(t *testing.T) {
+    l := New("")
+    at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+    l.Record([2][]Entrant{team("pikachu"), team("onix")}, 0, at)
+
+    p, _ := l.Get("pikachu")
+    o, _ := l.Get("onix")
+    if p.Rating != 1516 || o.Rating != 1484 {
+        t.Fatalf("expected 1516/1484, got %v/%v", p.Rating, o.Rating)
+    }
+    if p.Wins != 1 || o.Losses != 1 || len(p.History) != 1 || p.History[0].Opponent != "onix" || p.History[0].Outcome != Win {
+        t.Fatalf("unexpected records %+v %+v", p, o)
+    }
+
+    l.Record([2][]Entrant{team("pikachu"), team("onix")}, -1, at)
+    p, _ = l.Get("pikachu")
+    if p.Draws != 1 || p.Rating >= 1516 {
+        t.Fatalf("expected a draw against a weaker player to cost rating, got %+v", p)
+    }
+
+    l.Record([2][]Entrant{team("pikachu"), team("pikachu")}, 0, at)
+    if p2, _ := l.Get("pikachu"); p2.Wins != 1 {
+        t.Fatalf("expected self battles to be ignored")
+    }
+
+    twins := [2][]Entrant{{{ID: "id-1", Name: "pikachu"}}, {{ID: "id-2", Name: "pikachu"}}}
+    l.Record(twins, 1, at)
+    if a, _ := l.Get("id-1"); a.Losses != 1 || a.History[0].Opponent != "pikachu" {
+        t.Fatalf("expected two pikachu to be rated separately, got %+v", a)
+    }
+}
+
+func TestRecordTeams(t *testing.T) {
+    l := New("")
+    l.Record([2][]Entrant{team("a", "b"), team("c")}, 1, time.Now())
+    a, _ := l.Get("a")
+    c, _ := l.Get("c")
+    if a.Rating != 1484 || c.Rating != 1516 || c.History[0].Opponent != "a and 1 others" {
+        t.Fatalf("unexpected team ratings %+v %+v", a, c)
+    }
+}
+
+func TestRecordIgnoresEmptySides(t *testing.T) {
+    l := New("")
+    l.Record([2][]Entrant{nil, team("pikachu")}, 1, time.Now())
+    if _, ok := l.Get("pikachu"); ok {
+        t.Fatalf("expected a battle against nobody to go unrated")
+    }
+    if got := teamLabel(nil); got != "nobody" {
+        t.Fatalf("unexpected label for an empty team: %q", got)
+    }
+}
+
+func TestSaveAndLoad(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "ladder.json")
+    l := New(path)
+    l.Record([2][]Entrant{team("pikachu"), team("onix")}, 0, time.Now())
+    l.Rename("pikachu", "raichu")
+    if err := l.Save(); err != nil {
+        t.Fatalf("Save error: %v", err)
+    }
+
+    loaded, err := Load(path)
+    if err != nil {
+        t.Fatalf("Load error: %v", err)
+    }
+    ranks := loaded.Rankings()
+    if len(ranks) != 2 || ranks[0].Name != "raichu" || ranks[0].ID != "pikachu" || ranks[1].Name != "onix" {
+        t.Fatalf("unexpected rankings %+v", ranks)
+    }
+
+    if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+        t.Fatalf("expected a missing file to load as empty, got %v", err)
+    }
+    os.WriteFile(path, []byte("{not json"), 0o644)
+    if _, err := Load(path); err == nil {
+        t.Fatalf("expected an error for a corrupt ladder")
+    }
+}
+
+func TestMigrateRekeysPlayersByID(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "ladder.json")
+    os.WriteFile(path, []byte(`[{"name": "pikachu", "rating": 1516, "wins": 1}, {"name": "mew", "rating": 1484, "losses": 1}]`), 0o644)
+    l, err := Load(path)
+    if err != nil {
+        t.Fatalf("Load error: %v", err)
+    }
+    changed := l.Migrate(func(name string) (Entrant, bool) {
+        if name == "pikachu" {
+            return Entrant{ID: "id-1", Name: "Sparky"}, true
+        }
+        return Entrant{}, false
+    })
+    if !changed {
+        t.Fatalf("expected the migration to report changes")
+    }
+    if p, ok := l.Get("id-1"); !ok || p.Name != "Sparky" || p.Rating != 1516 {
+        t.Fatalf("expected pikachu's record under its ID, got %+v", p)
+    }
+    if p, ok := l.Get("mew"); !ok || p.ID != "mew" {
+        t.Fatalf("expected an unresolved name to stay keyed by name, got %+v", p)
+    }
+    if l.Migrate(func(string) (Entrant, bool) { return Entrant{}, false }) {
+        t.Fatalf("expected a second migration to be a no-op")
+    }
+}
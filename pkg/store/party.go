@@ -0,0 +1,96 @@
+package store
+
+import (
+    "fmt"
+    "sort"
+)
+
+const MaxPartySize = 6
+
+type Party struct {
+    Name    string   `json:"name"`
+    Members []string `json:"members"`
+}
+
+// SetParty creates or replaces a party. Members may be given by any
+// reference Resolve accepts and are stored by ID; each may appear only
+// once.
+func (s *Store) SetParty(name string, members []string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if len(members) == 0 {
+        return fmt.Errorf("a party needs at least one pokemon")
+    }
+    if len(members) > MaxPartySize {
+        return fmt.Errorf("a party can have at most %d pokemon", MaxPartySize)
+    }
+    ids := make([]string, 0, len(members))
+    seen := make(map[string]bool)
+    for _, m := range members {
+        id, err := s.resolve(m)
+        if err != nil {
+            return err
+        }
+        if seen[id] {
+            return fmt.Errorf("%s is already in the party", m)
+        }
+        seen[id] = true
+        ids = append(ids, id)
+    }
+    s.parties[name] = Party{Name: name, Members: ids}
+    s.autosave()
+    return nil
+}
+
+func (s *Store) Party(name string) (Party, bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    p, ok := s.parties[name]
+    p.Members = append([]string(nil), p.Members...)
+    return p, ok
+}
+
+func (s *Store) DeleteParty(name string) bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    _, ok := s.parties[name]
+    if ok {
+        delete(s.parties, name)
+        s.autosave()
+    }
+    return ok
+}
+
+func (s *Store) PartyNames() []string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    names := make([]string, 0, len(s.parties))
+    for n := range s.parties {
+        names = append(names, n)
+    }
+    sort.Strings(names)
+    return names
+}
+
+func sortParties(parties []Party) {
+    sort.Slice(parties, func(i, j int) bool { return parties[i].Name < parties[j].Name })
+}
+
+// removeMember drops a released Pokémon from every party, deleting parties
+// left empty. The caller must hold s.mu.
+func (s *Store) removeMember(id string) {
+    for name, p := range s.parties {
+        var kept []string
+        for _, m := range p.Members {
+            if m != id {
+                kept = append(kept, m)
+            }
+        }
+        if len(kept) == 0 {
+            delete(s.parties, name)
+            continue
+        }
+        p.Members = kept
+        s.parties[name] = p
+    }
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+    "testing"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func TestSetParty(t *testing.T) {
+    s := NewStore()
+    ids := make(map[string]string)
+    for _, n := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+        ids[n] = s.Add(api.Pokemon{Name: n}).ID
+    }
+
+    if err := s.SetParty("red", []string{"a", "b"}); err != nil {
+        t.Fatalf("SetParty error: %v", err)
+    }
+    p, ok := s.Party("red")
+    if !ok || len(p.Members) != 2 || p.Members[0] != ids["a"] {
+        t.Fatalf("unexpected party %+v", p)
+    }
+
+    if err := s.SetParty("big", []string{"a", "b", "c", "d", "e", "f", "g"}); err == nil {
+        t.Fatalf("expected error for a party of seven")
+    }
+    if err := s.SetParty("dup", []string{"a", "a"}); err == nil {
+        t.Fatalf("expected error for duplicate members")
+    }
+    if err := s.SetParty("ghost", []string{"mew"}); err == nil {
+        t.Fatalf("expected error for an uncaught member")
+    }
+    if names := s.PartyNames(); len(names) != 1 || names[0] != "red" {
+        t.Fatalf("unexpected party names %v", names)
+    }
+    if !s.DeleteParty("red") || s.DeleteParty("red") {
+        t.Fatalf("expected delete to succeed once")
+    }
+}
+
+func TestPartiesFollowEvolutionAndRelease(t *testing.T) {
+    s := NewStore()
+    s.Add(api.Pokemon{Name: "charmander"})
+    s.Add(api.Pokemon{Name: "squirtle"})
+    if err := s.SetParty("red", []string{"charmander", "squirtle"}); err != nil {
+        t.Fatalf("SetParty error: %v", err)
+    }
+    if _, err := s.Evolve("charmander", api.Pokemon{Name: "charmeleon"}, time.Now()); err != nil {
+        t.Fatalf("Evolve error: %v", err)
+    }
+    p, _ := s.Party("red")
+    if e, _ := s.Get(p.Members[0]); e.Name != "charmeleon" {
+        t.Fatalf("expected party to follow the evolution, got %+v", e)
+    }
+
+    if _, err := s.Release("squirtle"); err != nil {
+        t.Fatalf("Release error: %v", err)
+    }
+    if p, _ := s.Party("red"); len(p.Members) != 1 {
+        t.Fatalf("expected released pokemon to leave the party, got %v", p.Members)
+    }
+    s.Release("charmeleon")
+    if _, ok := s.Party("red"); ok {
+        t.Fatalf("expected an emptied party to be deleted")
+    }
+}
@@ -1,39 +1,312 @@
 package store
 
 import (
+    "crypto/rand"
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
     "sync"
+    "time"
+    "unicode"
 
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
 )
 
+const DefaultLevel = 5
+const DefaultHappiness = 70
+const MaxLevel = 100
+const MaxHappiness = 255
+
+// minPrefix is the shortest ID prefix accepted as a reference.
+const minPrefix = 4
+
+var (
+    ErrNotCaught = errors.New("you have not caught")
+    ErrAmbiguous = errors.New("more than one pokemon matches")
+)
+
+// Entry is one owned Pokémon. Name is its species form, as returned by
+// PokeAPI; ID and Nickname identify this individual.
+type Entry struct {
+    ID       string `json:"id"`
+    Nickname string `json:"nickname,omitempty"`
+    api.Pokemon
+    Level      int            `json:"level"`
+    Experience int            `json:"experience"`
+    GrowthRate string         `json:"growth_rate,omitempty"`
+    IVs        map[string]int `json:"ivs,omitempty"`
+    EVs        map[string]int `json:"evs,omitempty"`
+    Nature     api.Nature     `json:"nature"`
+    Shiny      bool           `json:"shiny,omitempty"`
+    HeldItem   string         `json:"held_item,omitempty"`
+    Happiness  int            `json:"happiness"`
+    CaughtAt   time.Time      `json:"caught_at"`
+    Location   string         `json:"location,omitempty"`
+    Ball       string         `json:"ball,omitempty"`
+    History    []Event        `json:"history,omitempty"`
+}
+
+// DisplayName is the nickname if there is one, otherwise the species.
+func (e Entry) DisplayName() string {
+    if e.Nickname != "" {
+        return e.Nickname
+    }
+    return e.Name
+}
+
+func (e Entry) ShortID() string {
+    if len(e.ID) < 8 {
+        return e.ID
+    }
+    return e.ID[:8]
+}
+
+type Event struct {
+    At          time.Time `json:"at"`
+    Description string    `json:"description"`
+}
+
+// Stats counts every catch, including Pokémon since released.
+type Stats struct {
+    Caught int `json:"caught"`
+    Shiny  int `json:"shiny"`
+}
+
 type Store struct {
     mu sync.Mutex
-    entries map[string]api.Pokemon
+    entries map[string]Entry
+    parties map[string]Party
+    stats   Stats
+
+    path        string
+    onSaveError func(error)
 }
 
 func NewStore() *Store {
-    return &Store{entries: make(map[string]api.Pokemon)}
+    return &Store{entries: make(map[string]Entry), parties: make(map[string]Party)}
 }
 
-func (s *Store) Add(p api.Pokemon) {
+func NewEntry(p api.Pokemon) Entry {
+    return Entry{Pokemon: p, Level: DefaultLevel, Happiness: DefaultHappiness}
+}
+
+// NewID returns a random version 4 UUID.
+func NewID() string {
+    var b [16]byte
+    if _, err := rand.Read(b[:]); err != nil {
+        panic(err)
+    }
+    b[6] = b[6]&0x0f | 0x40
+    b[8] = b[8]&0x3f | 0x80
+    return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (s *Store) Add(p api.Pokemon) Entry {
+    return s.Put(NewEntry(p))
+}
+
+// Put stores e as a new catch unless it already has an ID, and returns it
+// as stored.
+func (s *Store) Put(e Entry) Entry {
     s.mu.Lock()
     defer s.mu.Unlock()
-    s.entries[p.Name] = p
+    if e.ID == "" {
+        e.ID = NewID()
+        s.stats.Caught++
+        if e.Shiny {
+            s.stats.Shiny++
+        }
+    }
+    s.entries[e.ID] = e
+    s.autosave()
+    return e
 }
 
-func (s *Store) Get(name string) (api.Pokemon, bool) {
+func (s *Store) Stats() Stats {
     s.mu.Lock()
     defer s.mu.Unlock()
-    p, ok := s.entries[name]
-    return p, ok
+    return s.stats
+}
+
+func (s *Store) Get(ref string) (Entry, bool) {
+    e, err := s.Resolve(ref)
+    return e, err == nil
 }
 
+// Resolve finds an owned Pokémon by ID, nickname, species (when only one
+// of that species is owned) or a unique ID prefix, in that order.
+func (s *Store) Resolve(ref string) (Entry, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    id, err := s.resolve(ref)
+    if err != nil {
+        return Entry{}, err
+    }
+    return s.entries[id], nil
+}
+
+// resolve returns the ID ref refers to. The caller must hold s.mu.
+func (s *Store) resolve(ref string) (string, error) {
+    if _, ok := s.entries[ref]; ok {
+        return ref, nil
+    }
+    for id, e := range s.entries {
+        if e.Nickname != "" && strings.EqualFold(e.Nickname, ref) {
+            return id, nil
+        }
+    }
+
+    var species []string
+    for id, e := range s.entries {
+        if e.Name == ref {
+            species = append(species, id)
+        }
+    }
+    switch len(species) {
+    case 1:
+        return species[0], nil
+    case 0:
+    default:
+        return "", fmt.Errorf("%w: you have %d %s; use a nickname or ID", ErrAmbiguous, len(species), ref)
+    }
+
+    if len(ref) >= minPrefix {
+        var matches []string
+        for id := range s.entries {
+            if strings.HasPrefix(id, strings.ToLower(ref)) {
+                matches = append(matches, id)
+            }
+        }
+        switch len(matches) {
+        case 1:
+            return matches[0], nil
+        case 0:
+        default:
+            return "", fmt.Errorf("%w: %d IDs start with %s", ErrAmbiguous, len(matches), ref)
+        }
+    }
+    return "", fmt.Errorf("%w %s", ErrNotCaught, ref)
+}
+
+func (s *Store) Update(ref string, fn func(*Entry)) (Entry, bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    id, err := s.resolve(ref)
+    if err != nil {
+        return Entry{}, false
+    }
+    e := s.entries[id]
+    fn(&e)
+    e.ID = id
+    s.entries[id] = e
+    s.autosave()
+    return e, true
+}
+
+func (s *Store) Evolve(ref string, into api.Pokemon, at time.Time) (Entry, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    id, err := s.resolve(ref)
+    if err != nil {
+        return Entry{}, err
+    }
+    e := s.entries[id]
+    from := e.Name
+    e.Pokemon = into
+    e.History = append(e.History, Event{At: at, Description: fmt.Sprintf("evolved from %s into %s", from, into.Name)})
+    s.entries[id] = e
+    s.autosave()
+    return e, nil
+}
+
+// SetNickname names an owned Pokémon. Nicknames are single words, unique
+// regardless of case, and may not shadow an owned species or an ID prefix,
+// so every reference keeps resolving; an empty nickname clears it.
+func (s *Store) SetNickname(ref, nickname string) (Entry, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    id, err := s.resolve(ref)
+    if err != nil {
+        return Entry{}, err
+    }
+    if nickname != "" {
+        if strings.ContainsFunc(nickname, unicode.IsSpace) {
+            return Entry{}, fmt.Errorf("a nickname must be a single word")
+        }
+        lower := strings.ToLower(nickname)
+        for other, e := range s.entries {
+            if other != id && strings.EqualFold(e.Nickname, nickname) {
+                return Entry{}, fmt.Errorf("%s (%s) is already called %s", e.Name, e.ShortID(), e.Nickname)
+            }
+            if e.Name == lower {
+                return Entry{}, fmt.Errorf("%s cannot be used as a nickname while you own a %s", nickname, e.Name)
+            }
+            if len(lower) >= minPrefix && strings.HasPrefix(other, lower) {
+                return Entry{}, fmt.Errorf("%s cannot be used as a nickname", nickname)
+            }
+        }
+    }
+    e := s.entries[id]
+    e.Nickname = nickname
+    s.entries[id] = e
+    s.autosave()
+    return e, nil
+}
+
+// Release removes an owned Pokémon and drops it from every party.
+func (s *Store) Release(ref string) (Entry, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    id, err := s.resolve(ref)
+    if err != nil {
+        return Entry{}, err
+    }
+    e := s.entries[id]
+    delete(s.entries, id)
+    s.removeMember(id)
+    s.autosave()
+    return e, nil
+}
+
+// ListNames lists the species owned, once each.
 func (s *Store) ListNames() []string {
     s.mu.Lock()
     defer s.mu.Unlock()
-    names := make([]string, 0, len(s.entries))
-    for n := range s.entries {
-        names = append(names, n)
+    seen := make(map[string]bool)
+    var names []string
+    for _, e := range s.entries {
+        if !seen[e.Name] {
+            seen[e.Name] = true
+            names = append(names, e.Name)
+        }
     }
     return names
 }
+
+// List returns every owned Pokémon ordered by species, then catch time.
+func (s *Store) List() []Entry {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.sorted()
+}
+
+// sorted lists entries by species, then catch time, then ID. The caller
+// must hold s.mu.
+func (s *Store) sorted() []Entry {
+    entries := make([]Entry, 0, len(s.entries))
+    for _, e := range s.entries {
+        entries = append(entries, e)
+    }
+    sort.Slice(entries, func(i, j int) bool {
+        a, b := entries[i], entries[j]
+        if a.Name != b.Name {
+            return a.Name < b.Name
+        }
+        if !a.CaughtAt.Equal(b.CaughtAt) {
+            return a.CaughtAt.Before(b.CaughtAt)
+        }
+        return a.ID < b.ID
+    })
+    return entries
+}
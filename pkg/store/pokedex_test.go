@@ -1,7 +1,10 @@
 package store
 
 import (
+    "errors"
+    "strings"
     "testing"
+    "time"
 
     "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
 )
@@ -39,3 +42,108 @@ func TestStoreListNames(t *testing.T) {
         }
     }
 }
+
+func TestStoreEvolveKeepsProgressAndRecordsHistory(t *testing.T) {
+    s := NewStore()
+    e := NewEntry(api.Pokemon{Name: "pichu"})
+    e.Level = 12
+    e.Happiness = 230
+    s.Put(e)
+
+    at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+    got, err := s.Evolve("pichu", api.Pokemon{Name: "pikachu"}, at)
+    if err != nil {
+        t.Fatalf("Evolve error: %v", err)
+    }
+    if _, ok := s.Get("pichu"); ok {
+        t.Fatalf("expected pichu to be replaced")
+    }
+    stored, ok := s.Get("pikachu")
+    if !ok {
+        t.Fatalf("expected pikachu in store")
+    }
+    if stored.Level != 12 || stored.Happiness != 230 || got.Name != "pikachu" {
+        t.Fatalf("evolution lost progress: %+v", stored)
+    }
+    if len(stored.History) != 1 || stored.History[0].Description != "evolved from pichu into pikachu" || !stored.History[0].At.Equal(at) {
+        t.Fatalf("unexpected history: %+v", stored.History)
+    }
+
+    if _, err := s.Evolve("missing", api.Pokemon{Name: "x"}, at); err == nil {
+        t.Fatalf("expected error evolving a missing pokemon")
+    }
+}
+
+func TestStoreUpdate(t *testing.T) {
+    s := NewStore()
+    s.Add(api.Pokemon{Name: "onix"})
+    got, ok := s.Update("onix", func(e *Entry) { e.HeldItem = "metal-coat" })
+    if !ok || got.HeldItem != "metal-coat" {
+        t.Fatalf("expected update to set held item, got %+v", got)
+    }
+    if stored, _ := s.Get("onix"); stored.HeldItem != "metal-coat" || stored.Level != DefaultLevel {
+        t.Fatalf("unexpected stored entry %+v", stored)
+    }
+    if _, ok := s.Update("missing", func(e *Entry) {}); ok {
+        t.Fatalf("expected update of missing entry to fail")
+    }
+}
+
+func TestStoreKeepsEveryCatch(t *testing.T) {
+    s := NewStore()
+    first := s.Add(api.Pokemon{Name: "pikachu"})
+    second := s.Add(api.Pokemon{Name: "pikachu"})
+    if first.ID == second.ID || len(s.List()) != 2 {
+        t.Fatalf("expected two distinct pikachu, got %+v", s.List())
+    }
+    if names := s.ListNames(); len(names) != 1 {
+        t.Fatalf("expected one species, got %v", names)
+    }
+
+    if _, err := s.Resolve("pikachu"); !errors.Is(err, ErrAmbiguous) {
+        t.Fatalf("expected an ambiguous species reference, got %v", err)
+    }
+    if e, err := s.Resolve(second.ID[:6]); err != nil || e.ID != second.ID {
+        t.Fatalf("expected ID prefix to resolve, got %+v %v", e, err)
+    }
+    if _, err := s.Resolve("mew"); !errors.Is(err, ErrNotCaught) || err.Error() != "you have not caught mew" {
+        t.Fatalf("unexpected error %v", err)
+    }
+
+    if _, err := s.SetNickname(first.ID, "Sparky"); err != nil {
+        t.Fatalf("SetNickname error: %v", err)
+    }
+    if e, ok := s.Get("sparky"); !ok || e.ID != first.ID || e.DisplayName() != "Sparky" {
+        t.Fatalf("expected nickname lookup to ignore case, got %+v", e)
+    }
+    if _, err := s.SetNickname(second.ID, "SPARKY"); err == nil {
+        t.Fatalf("expected duplicate nicknames to be rejected")
+    }
+    if _, err := s.SetNickname(second.ID, "Mr Sparky"); err == nil {
+        t.Fatalf("expected a nickname with spaces to be rejected")
+    }
+    charmander := s.Add(api.Pokemon{Name: "charmander"})
+    if _, err := s.SetNickname(charmander.ID, "Pikachu"); err == nil {
+        t.Fatalf("expected a nickname shadowing an owned species to be rejected")
+    }
+    if _, err := s.SetNickname(charmander.ID, strings.ToUpper(second.ID[:5])); err == nil {
+        t.Fatalf("expected a nickname shadowing an ID prefix to be rejected")
+    }
+
+    if _, err := s.Release("sparky"); err != nil {
+        t.Fatalf("Release error: %v", err)
+    }
+    if e, ok := s.Get("pikachu"); !ok || e.ID != second.ID {
+        t.Fatalf("expected the remaining pikachu to resolve by species, got %+v", e)
+    }
+}
+
+func TestNewID(t *testing.T) {
+    id := NewID()
+    if len(id) != 36 || id[14] != '4' || strings.Count(id, "-") != 4 {
+        t.Fatalf("expected a version 4 UUID, got %q", id)
+    }
+    if NewID() == id {
+        t.Fatalf("expected unique IDs")
+    }
+}
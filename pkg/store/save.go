@@ -0,0 +1,133 @@
+package store
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/internal/atomicfile"
+)
+
+// SaveVersion is bumped whenever the save file layout changes. Version 1
+// keyed Pokémon and party members by species name.
+const SaveVersion = 2
+
+var ErrCorrupt = errors.New("save file is corrupt")
+
+type saveFile struct {
+    Version int     `json:"version"`
+    Entries []Entry `json:"entries"`
+    Parties []Party `json:"parties"`
+    Stats   *Stats  `json:"stats,omitempty"`
+}
+
+type Option func(*Store)
+
+// WithSaveErrorHandler is called whenever an autosave fails. Without one,
+// autosave errors are dropped.
+func WithSaveErrorHandler(fn func(error)) Option {
+    return func(s *Store) { s.onSaveError = fn }
+}
+
+// Open loads the store saved at path, or starts an empty one if the file
+// does not exist yet. Every later change is saved back to path.
+func Open(path string, opts ...Option) (*Store, error) {
+    s := NewStore()
+    s.path = path
+    for _, opt := range opts {
+        opt(s)
+    }
+
+    data, err := os.ReadFile(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return s, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    var f saveFile
+    if err := json.Unmarshal(data, &f); err != nil {
+        return nil, fmt.Errorf("%w: %s: %v", ErrCorrupt, path, err)
+    }
+    switch {
+    case f.Version == 0:
+        return nil, fmt.Errorf("%w: %s has no version", ErrCorrupt, path)
+    case f.Version > SaveVersion:
+        return nil, fmt.Errorf("%s was written by a newer Pokedex (save version %d, this one supports %d)", path, f.Version, SaveVersion)
+    }
+    if f.Version == 1 {
+        migrateV1(&f)
+    }
+    for _, e := range f.Entries {
+        s.entries[e.ID] = e
+    }
+    for _, p := range f.Parties {
+        s.parties[p.Name] = p
+    }
+    if f.Stats != nil {
+        s.stats = *f.Stats
+    } else {
+        s.stats = countStats(f.Entries)
+    }
+    return s, nil
+}
+
+// migrateV1 gives every entry an ID and points parties at those IDs.
+func migrateV1(f *saveFile) {
+    ids := make(map[string]string, len(f.Entries))
+    for i := range f.Entries {
+        f.Entries[i].ID = NewID()
+        ids[f.Entries[i].Name] = f.Entries[i].ID
+    }
+    for i, p := range f.Parties {
+        for j, m := range p.Members {
+            f.Parties[i].Members[j] = ids[m]
+        }
+    }
+}
+
+// countStats rebuilds the catch counters for saves that predate them,
+// from the Pokémon still owned.
+func countStats(entries []Entry) Stats {
+    st := Stats{Caught: len(entries)}
+    for _, e := range entries {
+        if e.Shiny {
+            st.Shiny++
+        }
+    }
+    return st
+}
+
+func (s *Store) Save() error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.save()
+}
+
+// save writes the store to its path. The caller must hold s.mu.
+func (s *Store) save() error {
+    if s.path == "" {
+        return nil
+    }
+    stats := s.stats
+    f := saveFile{Version: SaveVersion, Stats: &stats}
+    f.Entries = s.sorted()
+    for _, p := range s.parties {
+        f.Parties = append(f.Parties, p)
+    }
+    sortParties(f.Parties)
+    data, err := json.MarshalIndent(f, "", "  ")
+    if err != nil {
+        return err
+    }
+    return atomicfile.WriteFile(s.path, data, 0o644)
+}
+
+// autosave saves after a mutation and reports failures to the handler.
+// The caller must hold s.mu.
+func (s *Store) autosave() {
+    if err := s.save(); err != nil && s.onSaveError != nil {
+        s.onSaveError(err)
+    }
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func TestOpenSavesAndReloads(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "save.json")
+    s, err := Open(path)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    if len(s.ListNames()) != 0 {
+        t.Fatalf("expected a new save to start empty")
+    }
+
+    s.Add(api.Pokemon{Name: "pikachu", Types: []string{"electric"}, Stats: map[string]int{"hp": 35}})
+    s.Update("pikachu", func(e *Entry) { e.Level = 12 })
+    s.Add(api.Pokemon{Name: "onix"})
+    if err := s.SetParty("red", []string{"pikachu", "onix"}); err != nil {
+        t.Fatalf("SetParty error: %v", err)
+    }
+    if _, err := s.Evolve("pikachu", api.Pokemon{Name: "raichu"}, time.Now()); err != nil {
+        t.Fatalf("Evolve error: %v", err)
+    }
+
+    reloaded, err := Open(path)
+    if err != nil {
+        t.Fatalf("reopen error: %v", err)
+    }
+    e, ok := reloaded.Get("raichu")
+    if !ok || e.Level != 12 || len(e.History) != 1 {
+        t.Fatalf("expected raichu at level 12 with its history, got %+v", e)
+    }
+    if _, ok := reloaded.Get("pikachu"); ok {
+        t.Fatalf("expected pikachu to be gone after evolving")
+    }
+    p, ok := reloaded.Party("red")
+    if !ok || p.Members[0] != e.ID {
+        t.Fatalf("expected party to survive a reload, got %+v", p)
+    }
+}
+
+func TestCatchStatsOutliveReleases(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "save.json")
+    s, err := Open(path)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    shiny := NewEntry(api.Pokemon{Name: "gyarados"})
+    shiny.Shiny = true
+    s.Put(shiny)
+    s.Add(api.Pokemon{Name: "magikarp"})
+    if _, err := s.Release("gyarados"); err != nil {
+        t.Fatalf("Release error: %v", err)
+    }
+
+    reloaded, err := Open(path)
+    if err != nil {
+        t.Fatalf("reopen error: %v", err)
+    }
+    if st := reloaded.Stats(); st != (Stats{Caught: 2, Shiny: 1}) {
+        t.Fatalf("unexpected stats after reload: %+v", st)
+    }
+}
+
+func TestOpenMigratesVersion1(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "save.json")
+    os.WriteFile(path, []byte(`{"version": 1, "entries": [{"name": "pikachu", "level": 9}, {"name": "onix", "level": 5}], "parties": [{"name": "red", "members": ["onix", "pikachu"]}]}`), 0o644)
+
+    s, err := Open(path)
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    pikachu, ok := s.Get("pikachu")
+    if !ok || pikachu.ID == "" || pikachu.Level != 9 {
+        t.Fatalf("expected pikachu with a fresh ID, got %+v", pikachu)
+    }
+    p, _ := s.Party("red")
+    if len(p.Members) != 2 || p.Members[1] != pikachu.ID {
+        t.Fatalf("expected party members to be migrated to IDs, got %v", p.Members)
+    }
+}
+
+func TestOpenRejectsCorruptFiles(t *testing.T) {
+    dir := t.TempDir()
+    path := filepath.Join(dir, "save.json")
+
+    os.WriteFile(path, []byte(`{"version": 1, "entries": [`), 0o644)
+    if _, err := Open(path); !errors.Is(err, ErrCorrupt) {
+        t.Fatalf("expected ErrCorrupt for truncated JSON, got %v", err)
+    }
+    os.WriteFile(path, []byte(`{"entries": []}`), 0o644)
+    if _, err := Open(path); !errors.Is(err, ErrCorrupt) {
+        t.Fatalf("expected ErrCorrupt without a version, got %v", err)
+    }
+    os.WriteFile(path, []byte(`{"version": 99}`), 0o644)
+    if _, err := Open(path); err == nil || errors.Is(err, ErrCorrupt) {
+        t.Fatalf("expected a version error for a newer save, got %v", err)
+    }
+}
+
+func TestAutosaveErrorsAreReported(t *testing.T) {
+    dir := filepath.Join(t.TempDir(), "data")
+    var got error
+    s, err := Open(filepath.Join(dir, "save.json"), WithSaveErrorHandler(func(err error) { got = err }))
+    if err != nil {
+        t.Fatalf("Open error: %v", err)
+    }
+    // A file where the data directory should be makes every save fail.
+    os.WriteFile(dir, nil, 0o644)
+
+    s.Add(api.Pokemon{Name: "pikachu"})
+    if got == nil {
+        t.Fatalf("expected the save error handler to be called")
+    }
+    if _, ok := s.Get("pikachu"); !ok {
+        t.Fatalf("expected the change to stay in memory")
+    }
+}
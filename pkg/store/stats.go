@@ -0,0 +1,94 @@
+package store
+
+import (
+    "math/rand"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+const (
+    MaxIV      = 31
+    MaxEV      = 252
+    MaxTotalEV = 510
+)
+
+// StatNames lists the six stats in their usual order.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// RollIVs picks an individual value between 0 and MaxIV for every stat.
+func RollIVs(rng *rand.Rand) map[string]int {
+    ivs := make(map[string]int, len(StatNames))
+    for _, stat := range StatNames {
+        ivs[stat] = rng.Intn(MaxIV + 1)
+    }
+    return ivs
+}
+
+// FinalStats applies the standard stat formula to the base stats, using
+// e's level, individual and effort values and nature.
+func (e Entry) FinalStats() map[string]int {
+    stats := make(map[string]int, len(e.Stats))
+    for name, base := range e.Stats {
+        v := (2*base + e.IVs[name] + e.EVs[name]/4) * e.Level / 100
+        if name == "hp" {
+            stats[name] = v + e.Level + 10
+            continue
+        }
+        stats[name] = int(float64(v+5) * e.Nature.Multiplier(name))
+    }
+    return stats
+}
+
+// GainEffort adds effort values, capped per stat and in total.
+func (e *Entry) GainEffort(yield map[string]int) {
+    total := 0
+    for _, ev := range e.EVs {
+        total += ev
+    }
+    for _, stat := range StatNames {
+        gain := yield[stat]
+        if room := MaxEV - e.EVs[stat]; gain > room {
+            gain = room
+        }
+        if room := MaxTotalEV - total; gain > room {
+            gain = room
+        }
+        if gain <= 0 {
+            continue
+        }
+        if e.EVs == nil {
+            e.EVs = make(map[string]int)
+        }
+        e.EVs[stat] += gain
+        total += gain
+    }
+}
+
+// ExperienceYield is the experience earned for defeating e.
+func (e Entry) ExperienceYield() int {
+    xp := e.BaseExperience * e.Level / 7
+    if xp < 1 {
+        return 1
+    }
+    return xp
+}
+
+// GainExperience adds xp and levels up along rate, returning the number of
+// levels gained. Entries with less experience than their level implies,
+// such as fresh catches, start from the minimum for that level.
+func (e *Entry) GainExperience(xp int, rate *api.GrowthRate) int {
+    if min := rate.Experience(e.Level); e.Experience < min {
+        e.Experience = min
+    }
+    e.Experience += xp
+    level := rate.Level(e.Experience)
+    if level > MaxLevel {
+        level = MaxLevel
+    }
+    if level <= e.Level {
+        return 0
+    }
+    gained := level - e.Level
+    e.Level = level
+    return gained
+}
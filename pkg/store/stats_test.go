@@ -0,0 +1,78 @@
+package store
+
+import (
+    "math/rand"
+    "testing"
+
+    "github.com/gitRasheed/boot.dev-go-pokedex-cli/pkg/api"
+)
+
+func TestFinalStatsUseLevelFormula(t *testing.T) {
+    e := NewEntry(api.Pokemon{Name: "pikachu", Stats: map[string]int{"hp": 35, "attack": 55}})
+    e.Level = 50
+    stats := e.FinalStats()
+    if stats["hp"] != 95 || stats["attack"] != 60 {
+        t.Fatalf("unexpected stats at level 50: %v", stats)
+    }
+}
+
+func TestGainExperienceFollowsGrowthRate(t *testing.T) {
+    rate := &api.GrowthRate{Name: "medium", Levels: []api.GrowthLevel{
+        {Level: 1, Experience: 0},
+        {Level: 5, Experience: 125},
+        {Level: 6, Experience: 216},
+        {Level: 7, Experience: 343},
+        {Level: 8, Experience: 512},
+    }}
+    e := NewEntry(api.Pokemon{Name: "pikachu"})
+    if gained := e.GainExperience(50, rate); gained != 0 || e.Experience != 175 || e.Level != 5 {
+        t.Fatalf("expected a fresh catch to start at 125 experience, got %+v", e)
+    }
+    if gained := e.GainExperience(200, rate); gained != 2 || e.Level != 7 {
+        t.Fatalf("expected two level-ups, got %d (level %d)", gained, e.Level)
+    }
+
+    defeated := NewEntry(api.Pokemon{Name: "rattata", BaseExperience: 51})
+    defeated.Level = 14
+    if got := defeated.ExperienceYield(); got != 102 {
+        t.Fatalf("expected 102 experience for a level 14 rattata, got %d", got)
+    }
+}
+
+func TestFinalStatsApplyIVsEVsAndNature(t *testing.T) {
+    e := NewEntry(api.Pokemon{Name: "pikachu", Stats: map[string]int{"hp": 35, "attack": 55, "special-attack": 50}})
+    e.Level = 50
+    e.IVs = map[string]int{"hp": 31, "attack": 31, "special-attack": 31}
+    e.EVs = map[string]int{"attack": 252}
+    e.Nature = api.Nature{Name: "adamant", IncreasedStat: "attack", DecreasedStat: "special-attack"}
+    stats := e.FinalStats()
+    if stats["hp"] != 110 || stats["attack"] != 117 || stats["special-attack"] != 63 {
+        t.Fatalf("unexpected stats: %v", stats)
+    }
+}
+
+func TestGainEffortIsCapped(t *testing.T) {
+    e := NewEntry(api.Pokemon{Name: "pikachu"})
+    e.EVs = map[string]int{"attack": 250, "speed": 200}
+    e.GainEffort(map[string]int{"attack": 3, "speed": 3})
+    if e.EVs["attack"] != 252 || e.EVs["speed"] != 203 {
+        t.Fatalf("expected attack to stop at %d, got %v", MaxEV, e.EVs)
+    }
+    e.EVs = map[string]int{"hp": 252, "defense": 252, "speed": 4}
+    e.GainEffort(map[string]int{"speed": 3})
+    if e.EVs["speed"] != 6 {
+        t.Fatalf("expected the %d total to cap the gain, got %v", MaxTotalEV, e.EVs)
+    }
+}
+
+func TestRollIVs(t *testing.T) {
+    ivs := RollIVs(rand.New(rand.NewSource(1)))
+    if len(ivs) != len(StatNames) {
+        t.Fatalf("expected an IV for every stat, got %v", ivs)
+    }
+    for stat, iv := range ivs {
+        if iv < 0 || iv > MaxIV {
+            t.Fatalf("%s IV %d out of range", stat, iv)
+        }
+    }
+}
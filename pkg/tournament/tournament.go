@@ -0,0 +1,183 @@
+package tournament
+
+import (
+    "fmt"
+    "sort"
+)
+
+// Game plays a single battle and returns 0 if a won, 1 if b won or -1 for
+// a draw.
+type Game func(a, b string) int
+
+type Series struct {
+    Players [2]string
+    Wins    [2]int
+    Draws   int
+    // Winner is empty when a round-robin series is tied.
+    Winner string
+}
+
+func (s Series) String() string {
+    if s.Winner == "" {
+        return fmt.Sprintf("%s drew with %s %d-%d", s.Players[0], s.Players[1], s.Wins[0], s.Wins[1])
+    }
+    w, l := 0, 1
+    if s.Winner == s.Players[1] {
+        w, l = 1, 0
+    }
+    return fmt.Sprintf("%s def. %s %d-%d", s.Players[w], s.Players[l], s.Wins[w], s.Wins[l])
+}
+
+// PlaySeries plays up to bestOf games, stopping once either side has won a
+// majority. Drawn games count towards bestOf.
+func PlaySeries(a, b string, bestOf int, game Game) Series {
+    if bestOf < 1 {
+        bestOf = 1
+    }
+    s := Series{Players: [2]string{a, b}}
+    need := bestOf/2 + 1
+    for played := 0; played < bestOf && s.Wins[0] < need && s.Wins[1] < need; played++ {
+        switch winner := game(a, b); winner {
+        case 0, 1:
+            s.Wins[winner]++
+        default:
+            s.Draws++
+        }
+    }
+    switch {
+    case s.Wins[0] > s.Wins[1]:
+        s.Winner = a
+    case s.Wins[1] > s.Wins[0]:
+        s.Winner = b
+    }
+    return s
+}
+
+type Round struct {
+    Name   string
+    Series []Series
+    Byes   []string
+}
+
+type Bracket struct {
+    Rounds   []Round
+    Champion string
+}
+
+// SingleElimination seeds entrants in the order given. When the field is
+// not a power of two the top seeds get byes in the first round; every
+// round pairs the best remaining seed with the worst. A tied series goes
+// to the higher seed.
+func SingleElimination(entrants []string, bestOf int, game Game) Bracket {
+    var br Bracket
+    if len(entrants) == 0 {
+        return br
+    }
+    alive := append([]string(nil), entrants...)
+    size := 1
+    for size < len(alive) {
+        size *= 2
+    }
+    byes := size - len(alive)
+
+    for len(alive) > 1 {
+        r := Round{Name: roundName(len(alive)+byes, len(br.Rounds)+1)}
+        r.Byes = append([]string(nil), alive[:byes]...)
+        next := append([]string(nil), r.Byes...)
+        playing := alive[byes:]
+        for i, j := 0, len(playing)-1; i < j; i, j = i+1, j-1 {
+            s := PlaySeries(playing[i], playing[j], bestOf, game)
+            if s.Winner == "" {
+                s.Winner = playing[i]
+            }
+            r.Series = append(r.Series, s)
+            next = append(next, s.Winner)
+        }
+        br.Rounds = append(br.Rounds, r)
+        alive = reseed(next, entrants)
+        byes = 0
+    }
+    br.Champion = alive[0]
+    return br
+}
+
+// reseed orders the survivors by their original seed.
+func reseed(survivors, seeds []string) []string {
+    rank := make(map[string]int, len(seeds))
+    for i, s := range seeds {
+        rank[s] = i
+    }
+    sort.Slice(survivors, func(i, j int) bool { return rank[survivors[i]] < rank[survivors[j]] })
+    return survivors
+}
+
+func roundName(field, n int) string {
+    switch field {
+    case 2:
+        return "Final"
+    case 4:
+        return "Semifinals"
+    case 8:
+        return "Quarterfinals"
+    }
+    return fmt.Sprintf("Round %d", n)
+}
+
+type Standing struct {
+    Name                string
+    Wins, Draws, Losses int
+    GamesWon, GamesLost int
+}
+
+// Points awards three for a series win and one for a drawn series.
+func (s Standing) Points() int {
+    return 3*s.Wins + s.Draws
+}
+
+// RoundRobin plays every entrant against every other once and ranks them
+// by points, then game difference, then name.
+func RoundRobin(entrants []string, bestOf int, game Game) ([]Series, []Standing) {
+    table := make(map[string]*Standing, len(entrants))
+    for _, e := range entrants {
+        table[e] = &Standing{Name: e}
+    }
+    var played []Series
+    for i := range entrants {
+        for j := i + 1; j < len(entrants); j++ {
+            s := PlaySeries(entrants[i], entrants[j], bestOf, game)
+            played = append(played, s)
+            a, b := table[entrants[i]], table[entrants[j]]
+            a.GamesWon += s.Wins[0]
+            a.GamesLost += s.Wins[1]
+            b.GamesWon += s.Wins[1]
+            b.GamesLost += s.Wins[0]
+            switch s.Winner {
+            case "":
+                a.Draws++
+                b.Draws++
+            case a.Name:
+                a.Wins++
+                b.Losses++
+            default:
+                b.Wins++
+                a.Losses++
+            }
+        }
+    }
+
+    standings := make([]Standing, 0, len(entrants))
+    for _, e := range entrants {
+        standings = append(standings, *table[e])
+    }
+    sort.Slice(standings, func(i, j int) bool {
+        a, b := standings[i], standings[j]
+        if a.Points() != b.Points() {
+            return a.Points() > b.Points()
+        }
+        if da, db := a.GamesWon-a.GamesLost, b.GamesWon-b.GamesLost; da != db {
+            return da > db
+        }
+        return a.Name < b.Name
+    })
+    return played, standings
+}
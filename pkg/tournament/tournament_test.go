@@ -0,0 +1,85 @@
+package tournament
+
+import (
+    "strings"
+    "testing"
+)
+
+// byStrength makes the entrant with the higher strength always win.
+func byStrength(strength map[string]int) Game {
+    return func(a, b string) int {
+        switch {
+        case strength[a] > strength[b]:
+            return 0
+        case strength[b] > strength[a]:
+            return 1
+        }
+        return -1
+    }
+}
+
+func TestPlaySeriesStopsAtMajority(t *testing.T) {
+    games := 0
+    results := []int{0, 1, 0, 0, 0}
+    s := PlaySeries("a", "b", 5, func(a, b string) int {
+        games++
+        return results[games-1]
+    })
+    if games != 4 || s.Wins != [2]int{3, 1} || s.Winner != "a" {
+        t.Fatalf("unexpected series after %d games: %+v", games, s)
+    }
+    if s.String() != "a def. b 3-1" {
+        t.Fatalf("unexpected string %q", s.String())
+    }
+
+    s = PlaySeries("a", "b", 3, func(a, b string) int { return -1 })
+    if s.Winner != "" || s.Draws != 3 {
+        t.Fatalf("expected a drawn series, got %+v", s)
+    }
+}
+
+func TestSingleEliminationWithByes(t *testing.T) {
+    strength := map[string]int{"a": 5, "b": 4, "c": 3, "d": 2, "e": 1}
+    br := SingleElimination([]string{"a", "b", "c", "d", "e"}, 3, byStrength(strength))
+    if br.Champion != "a" {
+        t.Fatalf("expected a to win, got %q", br.Champion)
+    }
+    if len(br.Rounds) != 3 {
+        t.Fatalf("expected 3 rounds, got %d", len(br.Rounds))
+    }
+    first := br.Rounds[0]
+    if strings.Join(first.Byes, ",") != "a,b,c" || len(first.Series) != 1 || first.Series[0].Winner != "d" {
+        t.Fatalf("unexpected first round %+v", first)
+    }
+    if br.Rounds[1].Name != "Semifinals" || br.Rounds[2].Name != "Final" {
+        t.Fatalf("unexpected round names %q, %q", br.Rounds[1].Name, br.Rounds[2].Name)
+    }
+    if semi := br.Rounds[1].Series[0]; semi.Players != [2]string{"a", "d"} {
+        t.Fatalf("expected top seed to meet the lowest survivor, got %v", semi.Players)
+    }
+}
+
+func TestSingleEliminationTieGoesToHigherSeed(t *testing.T) {
+    br := SingleElimination([]string{"a", "b"}, 1, func(a, b string) int { return -1 })
+    if br.Champion != "a" {
+        t.Fatalf("expected higher seed to advance on a tie, got %q", br.Champion)
+    }
+}
+
+func TestRoundRobinStandings(t *testing.T) {
+    strength := map[string]int{"a": 1, "b": 3, "c": 2, "d": 2}
+    series, standings := RoundRobin([]string{"a", "b", "c", "d"}, 1, byStrength(strength))
+    if len(series) != 6 {
+        t.Fatalf("expected 6 series, got %d", len(series))
+    }
+    var order []string
+    for _, s := range standings {
+        order = append(order, s.Name)
+    }
+    if strings.Join(order, ",") != "b,c,d,a" {
+        t.Fatalf("unexpected standings order %v", order)
+    }
+    if c := standings[1]; c.Wins != 1 || c.Draws != 1 || c.Losses != 1 || c.Points() != 4 {
+        t.Fatalf("unexpected record for c: %+v", c)
+    }
+}